@@ -128,6 +128,20 @@ func toStringSlice(ids []ID) []string {
 	return result
 }
 
+// chunkIDs splits ids into consecutive batches of at most size IDs, for
+// endpoints that cap the number of IDs accepted in a single call.
+func chunkIDs(ids []ID, size int) [][]ID {
+	var chunks [][]ID
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
+
 // GetAlbums gets Spotify Catalog information for multiple albums, given their
 // Spotify IDs.  It supports up to 20 IDs in a single call.  Albums are returned
 // in the order requested.  If an album is not found, that position in the
@@ -2,6 +2,7 @@ package spotify
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -35,8 +36,12 @@ func TestFindAlbumBadID(t *testing.T) {
 	if album != nil {
 		t.Fatal("Expected nil album, got", album.Name)
 	}
-	se, ok := err.(Error)
-	if !ok {
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Error("Expected *NotFoundError, got", err)
+	}
+	var se Error
+	if !errors.As(err, &se) {
 		t.Error("Expected spotify error, got", err)
 	}
 	if se.Status != 404 {
@@ -2,7 +2,9 @@ package spotify
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -45,30 +47,40 @@ func (c *Client) GetArtist(ctx context.Context, id ID) (*FullArtist, error) {
 }
 
 // GetArtists gets spotify catalog information for several artists based on their
-// Spotify IDs.  It supports up to 50 artists in a single call.  Artists are
+// Spotify IDs.  The API supports up to 50 artists in a single call; larger sets
+// of IDs are split into several calls transparently.  Artists are
 // returned in the order requested.  If an artist is not found, that position
 // in the result will be nil.  Duplicate IDs will result in duplicate artists
 // in the result.
 func (c *Client) GetArtists(ctx context.Context, ids ...ID) ([]*FullArtist, error) {
-	spotifyURL := fmt.Sprintf("%sartists?ids=%s", c.baseURL, strings.Join(toStringSlice(ids), ","))
+	artists := make([]*FullArtist, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, 50) {
+		spotifyURL := fmt.Sprintf("%sartists?ids=%s", c.baseURL, strings.Join(toStringSlice(chunk), ","))
 
-	var a struct {
-		Artists []*FullArtist
-	}
+		var a struct {
+			Artists []*FullArtist `json:"artists"`
+		}
 
-	err := c.get(ctx, spotifyURL, &a)
-	if err != nil {
-		return nil, err
+		err := c.get(ctx, spotifyURL, &a)
+		if err != nil {
+			return nil, err
+		}
+
+		artists = append(artists, a.Artists...)
 	}
 
-	return a.Artists, nil
+	return artists, nil
 }
 
 // GetArtistsTopTracks gets Spotify catalog information about an artist's top
 // tracks in a particular country.  It returns a maximum of 10 tracks.  The
-// country is specified as an ISO 3166-1 alpha-2 country code.
+// country is specified as an ISO 3166-1 alpha-2 country code and is required
+// by the API.
 func (c *Client) GetArtistsTopTracks(ctx context.Context, artistID ID, country string) ([]FullTrack, error) {
-	spotifyURL := fmt.Sprintf("%sartists/%s/top-tracks?country=%s", c.baseURL, artistID, country)
+	if country == "" {
+		return nil, errors.New("spotify: a country is required to get an artist's top tracks")
+	}
+	spotifyURL := fmt.Sprintf("%sartists/%s/top-tracks?country=%s", c.baseURL, artistID, url.QueryEscape(country))
 
 	var t struct {
 		Tracks []FullTrack `json:"tracks"`
@@ -16,7 +16,9 @@ package spotify
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -95,6 +97,53 @@ func TestFindArtist(t *testing.T) {
 	}
 }
 
+func TestFindArtists(t *testing.T) {
+	client, server := testClientFile(http.StatusOK, "test_data/find_artists.txt")
+	defer server.Close()
+
+	artists, err := client.GetArtists(context.Background(), "0oSGxfWSnnOXhD2fKuz2Gy", "notanartist", "3dBVyJ7JuOMt4GE9607Qin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(artists); l != 3 {
+		t.Fatalf("Got %d artists, expected 3\n", l)
+	}
+	if artists[0].Name != "David Bowie" {
+		t.Error("Got ", artists[0].Name, ", wanted David Bowie")
+	}
+	if artists[1] != nil {
+		t.Error("Expected nil artist for unknown ID")
+	}
+	if g := artists[2].Genres; len(g) != 2 || g[1] != "protopunk" {
+		t.Error("Unexpected genres:", g)
+	}
+}
+
+func TestFindArtistsBatched(t *testing.T) {
+	client, server, batches := batchEchoServer(t, "artists")
+	defer server.Close()
+
+	ids := make([]ID, 120)
+	for i := range ids {
+		ids[i] = ID(fmt.Sprintf("artist%d", i))
+	}
+	artists, err := client.GetArtists(context.Background(), ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*batches, []int{50, 50, 20}) {
+		t.Errorf("Expected batches of [50 50 20], got %v", *batches)
+	}
+	if len(artists) != len(ids) {
+		t.Fatalf("Got %d artists, expected %d", len(artists), len(ids))
+	}
+	for i, a := range artists {
+		if a.ID != ids[i] {
+			t.Fatalf("Artist %d out of order: got %s", i, a.ID)
+		}
+	}
+}
+
 func TestArtistTopTracks(t *testing.T) {
 	client, server := testClientFile(http.StatusOK, "test_data/artist_top_tracks.txt")
 	defer server.Close()
@@ -116,6 +165,18 @@ func TestArtistTopTracks(t *testing.T) {
 	}
 }
 
+func TestArtistTopTracksRequiresCountry(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"tracks": []}`, func(r *http.Request) {
+		t.Error("No request should have been sent")
+	})
+	defer server.Close()
+
+	_, err := client.GetArtistsTopTracks(context.Background(), ID("43ZHCT0cAZBISjO8DG9PnE"), "")
+	if err == nil {
+		t.Error("Expected an error for a missing country")
+	}
+}
+
 func TestRelatedArtists(t *testing.T) {
 	client, server := testClientFile(http.StatusOK, "test_data/related_artists.txt")
 	defer server.Close()
@@ -106,19 +106,26 @@ const (
 
 // GetAudioFeatures queries the Spotify Web API for various
 // high-level acoustic attributes of audio tracks.
+// The API supports up to 100 tracks in a single call; larger sets of IDs
+// are split into several calls transparently.
 // Objects are returned in the order requested.  If an object
 // is not found, a nil value is returned in the appropriate position.
 func (c *Client) GetAudioFeatures(ctx context.Context, ids ...ID) ([]*AudioFeatures, error) {
-	url := fmt.Sprintf("%saudio-features?ids=%s", c.baseURL, strings.Join(toStringSlice(ids), ","))
+	features := make([]*AudioFeatures, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, 100) {
+		url := fmt.Sprintf("%saudio-features?ids=%s", c.baseURL, strings.Join(toStringSlice(chunk), ","))
 
-	temp := struct {
-		F []*AudioFeatures `json:"audio_features"`
-	}{}
+		temp := struct {
+			F []*AudioFeatures `json:"audio_features"`
+		}{}
 
-	err := c.get(ctx, url, &temp)
-	if err != nil {
-		return nil, err
+		err := c.get(ctx, url, &temp)
+		if err != nil {
+			return nil, err
+		}
+
+		features = append(features, temp.F...)
 	}
 
-	return temp.F, nil
+	return features, nil
 }
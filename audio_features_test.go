@@ -2,7 +2,10 @@ package spotify
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -79,7 +82,7 @@ func TestAudioFeatures(t *testing.T) {
 		"abc", // intentionally throw a bad one in
 		"24JygzOLM0EmRQeGtFcIcG",
 	}
-	features, err := c.GetAudioFeatures(context.Background())
+	features, err := c.GetAudioFeatures(context.Background(), ids...)
 	if err != nil {
 		t.Error(err)
 	}
@@ -93,3 +96,45 @@ func TestAudioFeatures(t *testing.T) {
 		t.Errorf("Want key G, got %v\n", features[0].Key)
 	}
 }
+
+func TestAudioFeaturesBatched(t *testing.T) {
+	c, server, batches := batchServer(t, func(w http.ResponseWriter, r *http.Request, ids []string) {
+		features := make([]string, len(ids))
+		for i, id := range ids {
+			// every tenth track has no features
+			if strings.HasSuffix(id, "0") {
+				features[i] = "null"
+				continue
+			}
+			features[i] = fmt.Sprintf(`{"id": %q}`, id)
+		}
+		fmt.Fprintf(w, `{"audio_features": [%s]}`, strings.Join(features, ","))
+	})
+	defer server.Close()
+
+	ids := make([]ID, 101)
+	for i := range ids {
+		ids[i] = ID(fmt.Sprintf("track%d", i))
+	}
+	features, err := c.GetAudioFeatures(context.Background(), ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*batches, []int{100, 1}) {
+		t.Errorf("Expected batches of [100 1], got %v", *batches)
+	}
+	if len(features) != len(ids) {
+		t.Fatalf("Want %d results, got %d", len(ids), len(features))
+	}
+	for i, f := range features {
+		if i%10 == 0 {
+			if f != nil {
+				t.Errorf("Want nil result at %d, got %#v", i, f)
+			}
+			continue
+		}
+		if f == nil || f.ID != ids[i] {
+			t.Fatalf("Result %d out of order: %#v", i, f)
+		}
+	}
+}
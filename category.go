@@ -3,6 +3,7 @@ package spotify
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
 // Category is used by Spotify to tag items in.  For example, on the Spotify
@@ -20,11 +21,12 @@ type Category struct {
 }
 
 // GetCategory gets a single category used to tag items in Spotify.
+// An unknown category ID results in a *NotFoundError.
 //
 // Supported options: Country, Locale
 func (c *Client) GetCategory(ctx context.Context, id string, opts ...RequestOption) (Category, error) {
 	cat := Category{}
-	spotifyURL := fmt.Sprintf("%sbrowse/categories/%s", c.baseURL, id)
+	spotifyURL := fmt.Sprintf("%sbrowse/categories/%s", c.baseURL, url.PathEscape(id))
 	if params := processOptions(opts...).urlParams.Encode(); params != "" {
 		spotifyURL += "?" + params
 	}
@@ -38,9 +40,11 @@ func (c *Client) GetCategory(ctx context.Context, id string, opts ...RequestOpti
 }
 
 // GetCategoryPlaylists gets a list of Spotify playlists tagged with a particular category.
-// Supported options: Country, Limit, Offset
+// An unknown category ID results in a *NotFoundError.
+//
+// Supported options: Country, Locale, Limit, Offset
 func (c *Client) GetCategoryPlaylists(ctx context.Context, catID string, opts ...RequestOption) (*SimplePlaylistPage, error) {
-	spotifyURL := fmt.Sprintf("%sbrowse/categories/%s/playlists", c.baseURL, catID)
+	spotifyURL := fmt.Sprintf("%sbrowse/categories/%s/playlists", c.baseURL, url.PathEscape(catID))
 	if params := processOptions(opts...).urlParams.Encode(); params != "" {
 		spotifyURL += "?" + params
 	}
@@ -2,6 +2,7 @@ package spotify
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -98,6 +99,60 @@ func TestGetCategoryPlaylistsOpt(t *testing.T) {
 	}
 }
 
+func TestGetCategoriesOpt(t *testing.T) {
+	client, server := testClientString(http.StatusOK, getCategories, func(r *http.Request) {
+		if r.URL.Path != "/browse/categories" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.RawQuery; q != "country=CA&limit=2&locale=fr_CA&offset=0" {
+			t.Errorf("Unexpected query %s", q)
+		}
+	})
+	defer server.Close()
+
+	page, err := client.GetCategories(context.Background(), Country("CA"), Locale("fr_CA"), Limit(2), Offset(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 31 || page.Next == "" {
+		t.Errorf("The page wasn't unwrapped: %+v", page.basePage)
+	}
+	if id := page.Categories[0].ID; id != "toplists" {
+		t.Errorf("Expected 'toplists', got '%s'", id)
+	}
+}
+
+func TestGetCategoryNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(*Client) error
+	}{
+		{"category", func(c *Client) error {
+			_, err := c.GetCategory(context.Background(), "not-a-category")
+			return err
+		}},
+		{"playlists", func(c *Client) error {
+			_, err := c.GetCategoryPlaylists(context.Background(), "not-a-category")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := testClientFile(http.StatusNotFound, "test_data/category_not_found.txt")
+			defer server.Close()
+
+			err := tt.call(client)
+			var nf *NotFoundError
+			if !errors.As(err, &nf) {
+				t.Fatalf("Expected *NotFoundError, got %v", err)
+			}
+			if nf.Err.Message != "Specified id doesn't exist" {
+				t.Errorf("Unexpected message %q", nf.Err.Message)
+			}
+		})
+	}
+}
+
 func TestGetCategoriesInvalidToken(t *testing.T) {
 	client, server := testClientString(http.StatusUnauthorized, invalidToken)
 	defer server.Close()
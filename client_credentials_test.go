@@ -0,0 +1,87 @@
+package spotify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// stubAccounts returns a server standing in for both the accounts service,
+// issuing a bearer token for the client credentials below, and the Web API.
+func stubAccounts(t *testing.T, auth *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/token" {
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "client-id" || secret != "client-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = io.WriteString(w, `{"error": "invalid_client"}`)
+				return
+			}
+			if gt := r.FormValue("grant_type"); gt != "client_credentials" {
+				t.Errorf("Expected grant_type client_credentials, got %q", gt)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"access_token": "stub-token", "token_type": "bearer", "expires_in": 3600}`)
+			return
+		}
+		*auth = append(*auth, r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+	}))
+}
+
+func TestNewClientCredentialsClient(t *testing.T) {
+	var auth []string
+	server := stubAccounts(t, &auth)
+	defer server.Close()
+
+	var notified []string
+	built := 0
+	c, err := NewClientCredentialsClient(context.Background(), "client-id", "client-secret",
+		WithBaseURL(server.URL+"/"),
+		WithTokenURL(server.URL+"/api/token"),
+		WithRoundTripper(func(next http.RoundTripper) http.RoundTripper {
+			built++
+			return next
+		}),
+		WithTokenNotify(func(t *oauth2.Token) { notified = append(notified, t.AccessToken) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built != 1 {
+		t.Errorf("Expected the middleware to be built once, got %d", built)
+	}
+
+	if _, err := c.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(auth) != 1 || auth[0] != "Bearer stub-token" {
+		t.Errorf("Expected the API call to carry the stub token, got %v", auth)
+	}
+	token, err := c.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "stub-token" {
+		t.Errorf("Expected Token to return the stub token, got %q", token.AccessToken)
+	}
+	if len(notified) != 1 || notified[0] != "stub-token" {
+		t.Errorf("Expected to be notified of the stub token once, got %v", notified)
+	}
+}
+
+func TestNewClientCredentialsClientInvalid(t *testing.T) {
+	var auth []string
+	server := stubAccounts(t, &auth)
+	defer server.Close()
+
+	_, err := NewClientCredentialsClient(context.Background(), "client-id", "wrong-secret",
+		WithTokenURL(server.URL+"/api/token"))
+	if err == nil {
+		t.Error("Expected an error for invalid credentials")
+	}
+}
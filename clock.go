@@ -0,0 +1,33 @@
+package spotify
+
+import (
+	"context"
+	"time"
+)
+
+// clock is the source of time used by the client when measuring request
+// latency and waiting between retries.  Tests replace it to observe backoff
+// without sleeping.
+type clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// realClock is the default clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+// clk returns the client's clock, falling back to the real one.
+func (c *Client) clk() clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+	return c.clock
+}
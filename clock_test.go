@@ -0,0 +1,81 @@
+package spotify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose time only advances when Sleep is called,
+// or by step every time it is read.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	step   time.Duration
+	sleeps []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now
+	f.now = f.now.Add(f.step)
+	return now
+}
+
+func (f *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sleeps = append(f.sleeps, d)
+	f.now = f.now.Add(d)
+	return ctx.Err()
+}
+
+func TestRetryBackoffWithFakeClock(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= 3 {
+			w.Header().Set("Retry-After", strconv.Itoa(attempts))
+			w.WriteHeader(rateLimitExceededStatusCode)
+			_, _ = io.WriteString(w, `{ "error": { "message": "slow down", "status": 429 } }`)
+			return
+		}
+		f, err := os.Open("test_data/new_releases.txt")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer f.Close()
+		_, _ = io.Copy(w, f)
+	}))
+	defer server.Close()
+
+	clk := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", autoRetry: true, clock: clk}
+
+	start := time.Now()
+	if _, err := client.NewReleases(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if real := time.Since(start); real > time.Second {
+		t.Errorf("Expected no real delay, took %s", real)
+	}
+
+	if attempts != 4 {
+		t.Errorf("Expected 4 attempts, got %d", attempts)
+	}
+	var total time.Duration
+	for _, d := range clk.sleeps {
+		total += d
+	}
+	if len(clk.sleeps) != 3 || total != 6*time.Second {
+		t.Errorf("Expected 3 sleeps totalling 6s, got %v", clk.sleeps)
+	}
+}
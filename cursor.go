@@ -1,14 +1,21 @@
 package spotify
 
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
 // This file contains the types that implement Spotify's cursor-based
 // paging object.  Like the standard paging object, this object is a
 // container for a set of items. Unlike the standard paging object, a
 // cursor-based paging object does not provide random access to the results.
 
-// Cursor contains a key that can be used to find the next set
-// of items.
+// Cursor contains the keys that can be used to find the next
+// or previous set of items.
 type Cursor struct {
-	After string `json:"after"`
+	After  string `json:"after"`
+	Before string `json:"before"`
 }
 
 // cursorPage contains all of the fields in a Spotify cursor-based
@@ -35,3 +42,39 @@ type FullArtistCursorPage struct {
 	cursorPage
 	Artists []FullArtist `json:"items"`
 }
+
+// RecentlyPlayedPage is a cursor-based paging object containing
+// a set of RecentlyPlayedItem objects.
+type RecentlyPlayedPage struct {
+	cursorPage
+	Items []RecentlyPlayedItem `json:"items"`
+}
+
+// cursorPageable is an internal interface for types that support
+// cursor-based paging by embedding cursorPage.
+type cursorPageable interface{ cursor() *cursorPage }
+
+func (c *cursorPage) cursor() *cursorPage { return c }
+
+// NextCursorPage fetches the next set of items and writes them into p.
+// It returns ErrNoMorePages if p has no After cursor or next link, which
+// happens once the last set of items has been fetched.
+func (c *Client) NextCursorPage(ctx context.Context, p cursorPageable) error {
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		return fmt.Errorf("spotify: p must be a non-nil pointer to a cursor page")
+	}
+
+	page := p.cursor()
+	nextURL := page.Next
+	if page.Cursor.After == "" || nextURL == "" {
+		return ErrNoMorePages
+	}
+
+	// Zero out the page so that we can overwrite it in the next
+	// call to get. This is necessary because encoding/json does
+	// not clear out existing values when unmarshaling JSON null.
+	val := reflect.ValueOf(p).Elem()
+	val.Set(reflect.Zero(val.Type()))
+
+	return c.get(ctx, nextURL, p)
+}
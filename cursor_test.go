@@ -0,0 +1,78 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecentlyPlayedCursor(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		file := "test_data/recently_played_cursor_1.txt"
+		if r.URL.Query().Get("before") != "" {
+			file = "test_data/recently_played_cursor_2.txt"
+		}
+		body, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// point the next link back at the test server
+		w.Write([]byte(strings.ReplaceAll(string(body), "https://api.spotify.com/v1/", "http://"+r.Host+"/")))
+	}))
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	page, err := client.RecentlyPlayed(context.Background(), Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(page.Items); l != 2 {
+		t.Fatalf("Got %d items, expected 2", l)
+	}
+	if page.Cursor.After != "1495915674721" || page.Cursor.Before != "1495915183103" {
+		t.Errorf("Unexpected cursors: %+v", page.Cursor)
+	}
+	if name := page.Items[1].Track.Name; name != "Dancing Queen" {
+		t.Errorf("Got %q, wanted Dancing Queen", name)
+	}
+
+	if err := client.NextCursorPage(context.Background(), page); err != nil {
+		t.Fatal(err)
+	}
+	if l := len(page.Items); l != 1 {
+		t.Fatalf("Got %d items on the second page, expected 1", l)
+	}
+	if name := page.Items[0].Track.Name; name != "Smells Like Teen Spirit" {
+		t.Errorf("Got %q, wanted Smells Like Teen Spirit", name)
+	}
+	if page.Cursor.After != "" || page.Next != "" {
+		t.Errorf("Expected the last page to have no cursors, got %+v", page.cursorPage)
+	}
+
+	if err := client.NextCursorPage(context.Background(), page); !errors.Is(err, ErrNoMorePages) {
+		t.Errorf("Expected ErrNoMorePages, got %v", err)
+	}
+	want := []string{"limit=2", "before=1495915183103&limit=2"}
+	if len(queries) != len(want) {
+		t.Fatalf("Got %d requests, expected %d: %v", len(queries), len(want), queries)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Errorf("Request %d: got query %q, want %q", i, queries[i], want[i])
+		}
+	}
+}
+
+func TestNextCursorPageNil(t *testing.T) {
+	client := &Client{http: http.DefaultClient}
+	var page *RecentlyPlayedPage
+	if err := client.NextCursorPage(context.Background(), page); err == nil {
+		t.Error("Expected an error for a nil page")
+	}
+}
@@ -0,0 +1,48 @@
+package spotify
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// decodeErrorSnippetBytes is how much of the body a DecodeError keeps.
+const decodeErrorSnippetBytes = 256
+
+// DecodeError is returned when a successful response can't be decoded into
+// the expected type, for example because of a malformed body.  It records
+// where the response came from and how it started, and unwraps to the
+// error returned by encoding/json.
+type DecodeError struct {
+	// URL is the URL of the request.
+	URL string
+	// Status is the HTTP status code of the response.
+	Status int
+	// Snippet holds the first bytes of the response body.
+	Snippet string
+	Err     error
+}
+
+func newDecodeError(url string, status int, body []byte, err error) *DecodeError {
+	if len(body) > decodeErrorSnippetBytes {
+		body = body[:decodeErrorSnippetBytes]
+		// don't cut a multi-byte character in half; invalid bytes
+		// elsewhere are kept, Error quotes them
+		for i := 1; i < utf8.UTFMax && i <= len(body); i++ {
+			if utf8.RuneStart(body[len(body)-i]) {
+				if !utf8.FullRune(body[len(body)-i:]) {
+					body = body[:len(body)-i]
+				}
+				break
+			}
+		}
+	}
+	return &DecodeError{URL: url, Status: status, Snippet: string(body), Err: err}
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("spotify: decoding response from %s (HTTP %d): %v; body: %q", e.URL, e.Status, e.Err, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
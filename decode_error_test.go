@@ -0,0 +1,98 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrorMalformedJSON(t *testing.T) {
+	c, s := testClientString(http.StatusOK, `{"genres": ["rock", }`)
+	defer s.Close()
+
+	_, err := c.GetAvailableGenreSeeds(context.Background())
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %v", err)
+	}
+	if !strings.HasSuffix(de.URL, "/recommendations/available-genre-seeds") || de.Status != http.StatusOK {
+		t.Errorf("Unexpected URL %q and status %d", de.URL, de.Status)
+	}
+	if de.Snippet != `{"genres": ["rock", }` {
+		t.Errorf("Unexpected snippet %q", de.Snippet)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, de.URL) || !strings.Contains(msg, `[\"rock\", }`) {
+		t.Errorf("Expected the error to mention the URL and the body, got %q", msg)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected the error to unwrap to *json.SyntaxError, got %T", de.Err)
+	}
+}
+
+func TestDecodeErrorWrongType(t *testing.T) {
+	c, s := testClientString(http.StatusOK, `{"genres": "rock"}`)
+	defer s.Close()
+
+	_, err := c.GetAvailableGenreSeeds(context.Background())
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected the error to unwrap to *json.UnmarshalTypeError, got %v", err)
+	}
+}
+
+func TestDecodeErrorSnippetTruncated(t *testing.T) {
+	body := `{"genres": [` + strings.Repeat(`"rock", `, 100)
+	c, s := testClientString(http.StatusOK, body)
+	defer s.Close()
+
+	_, err := c.GetAvailableGenreSeeds(context.Background())
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %v", err)
+	}
+	if de.Snippet != body[:decodeErrorSnippetBytes] {
+		t.Errorf("Expected the first %d bytes of the body, got %q", decodeErrorSnippetBytes, de.Snippet)
+	}
+}
+
+func TestDecodeBodyTooLarge(t *testing.T) {
+	c, s := testClientString(http.StatusOK, `{"genres": ["rock", "pop"]}`)
+	defer s.Close()
+	c.maxResponseBytes = 8
+
+	_, err := c.GetAvailableGenreSeeds(context.Background())
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Expected *ResponseTooLargeError, got %v", err)
+	}
+	var de *DecodeError
+	if errors.As(err, &de) {
+		t.Error("An oversized body should not be reported as a DecodeError")
+	}
+}
+
+func TestDecodeErrorSnippetEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		// a Latin-1 body, invalid as UTF-8 from its fourth byte
+		{"invalid byte", `{"g` + "\xe9" + strings.Repeat("a", 400), `{"g` + "\xe9" + strings.Repeat("a", decodeErrorSnippetBytes-4)},
+		{"split character", strings.Repeat("a", decodeErrorSnippetBytes-1) + "é", strings.Repeat("a", decodeErrorSnippetBytes-1)},
+		{"whole character", strings.Repeat("a", decodeErrorSnippetBytes-2) + "éa", strings.Repeat("a", decodeErrorSnippetBytes-2) + "é"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			de := newDecodeError("https://api.spotify.com/v1/me", http.StatusOK, []byte(tt.body), errors.New("invalid"))
+			if de.Snippet != tt.want {
+				t.Errorf("Expected snippet %q, got %q", tt.want, de.Snippet)
+			}
+		})
+	}
+}
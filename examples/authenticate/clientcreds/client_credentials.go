@@ -9,28 +9,19 @@ package main
 import (
 	"context"
 	"fmt"
-	"github.com/cappfm/spotify-go/v2/auth"
 	"log"
 	"os"
 
 	"github.com/cappfm/spotify-go/v2"
-	"golang.org/x/oauth2/clientcredentials"
 )
 
 func main() {
 	ctx := context.Background()
-	config := &clientcredentials.Config{
-		ClientID:     os.Getenv("SPOTIFY_ID"),
-		ClientSecret: os.Getenv("SPOTIFY_SECRET"),
-		TokenURL:     spotifyauth.TokenURL,
-	}
-	token, err := config.Token(ctx)
+	client, err := spotify.NewClientCredentialsClient(ctx, os.Getenv("SPOTIFY_ID"), os.Getenv("SPOTIFY_SECRET"))
 	if err != nil {
 		log.Fatalf("couldn't get token: %v", err)
 	}
 
-	httpClient := spotifyauth.New().Client(ctx, token)
-	client := spotify.New(httpClient)
 	msg, page, err := client.FeaturedPlaylists(ctx)
 	if err != nil {
 		log.Fatalf("couldn't get features playlists: %v", err)
@@ -1,7 +1,9 @@
 package spotify
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,36 +11,48 @@ import (
 )
 
 // UserHasTracks checks if one or more tracks are saved to the current user's
-// "Your Music" library.
+// "Your Music" library.  The results are in the order of the IDs given; more
+// than 50 IDs are checked in several calls transparently.
 func (c *Client) UserHasTracks(ctx context.Context, ids ...ID) ([]bool, error) {
 	return c.libraryContains(ctx, "tracks", ids...)
 }
 
 // UserHasAlbums checks if one or more albums are saved to the current user's
-// "Your Albums" library.
+// "Your Albums" library.  The results are in the order of the IDs given; more
+// than 50 IDs are checked in several calls transparently.
 func (c *Client) UserHasAlbums(ctx context.Context, ids ...ID) ([]bool, error) {
 	return c.libraryContains(ctx, "albums", ids...)
 }
 
 func (c *Client) libraryContains(ctx context.Context, typ string, ids ...ID) ([]bool, error) {
-	if l := len(ids); l == 0 || l > 50 {
-		return nil, errors.New("spotify: supports 1 to 50 IDs per call")
+	if len(ids) == 0 {
+		return nil, errors.New("spotify: at least one ID is required")
 	}
-	spotifyURL := fmt.Sprintf("%sme/%s/contains?ids=%s", c.baseURL, typ, strings.Join(toStringSlice(ids), ","))
 
-	var result []bool
+	result := make([]bool, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, 50) {
+		spotifyURL := fmt.Sprintf("%sme/%s/contains?ids=%s", c.baseURL, typ, strings.Join(toStringSlice(chunk), ","))
 
-	err := c.get(ctx, spotifyURL, &result)
-	if err != nil {
-		return nil, err
+		var contains []bool
+
+		err := c.get(ctx, spotifyURL, &contains)
+		if err != nil {
+			return nil, err
+		}
+		if len(contains) != len(chunk) {
+			return nil, fmt.Errorf("spotify: expected %d results, got %d", len(chunk), len(contains))
+		}
+
+		result = append(result, contains...)
 	}
 
-	return result, err
+	return result, nil
 }
 
 // AddTracksToLibrary saves one or more tracks to the current user's
 // "Your Music" library.  This call requires the ScopeUserLibraryModify scope.
-// A track can only be saved once; duplicate IDs are ignored.
+// A track can only be saved once; duplicate IDs are ignored.  More than 50
+// IDs are saved in several calls transparently.
 func (c *Client) AddTracksToLibrary(ctx context.Context, ids ...ID) error {
 	return c.modifyLibrary(ctx, "tracks", true, ids...)
 }
@@ -47,6 +61,7 @@ func (c *Client) AddTracksToLibrary(ctx context.Context, ids ...ID) error {
 // "Your Music" library.  This call requires the ScopeUserModifyLibrary scope.
 // Trying to remove a track when you do not have the user's authorization
 // results in a `spotify.Error` with the status code set to http.StatusUnauthorized.
+// More than 50 IDs are removed in several calls transparently.
 func (c *Client) RemoveTracksFromLibrary(ctx context.Context, ids ...ID) error {
 	return c.modifyLibrary(ctx, "tracks", false, ids...)
 }
@@ -67,21 +82,31 @@ func (c *Client) RemoveAlbumsFromLibrary(ctx context.Context, ids ...ID) error {
 }
 
 func (c *Client) modifyLibrary(ctx context.Context, typ string, add bool, ids ...ID) error {
-	if l := len(ids); l == 0 || l > 50 {
-		return errors.New("spotify: this call supports 1 to 50 IDs per call")
+	if len(ids) == 0 {
+		return errors.New("spotify: at least one ID is required")
 	}
-	spotifyURL := fmt.Sprintf("%sme/%s?ids=%s", c.baseURL, typ, strings.Join(toStringSlice(ids), ","))
+	spotifyURL := fmt.Sprintf("%sme/%s", c.baseURL, typ)
 	method := "DELETE"
 	if add {
 		method = "PUT"
 	}
-	req, err := http.NewRequest(method, spotifyURL, nil)
-	if err != nil {
-		return err
-	}
-	err = c.execute(req, nil)
-	if err != nil {
-		return err
+
+	for _, chunk := range chunkIDs(ids, 50) {
+		body, err := json.Marshal(struct {
+			IDs []ID `json:"ids"`
+		}{chunk})
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, method, spotifyURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		err = c.execute(req, nil)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -2,7 +2,12 @@ package spotify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -107,3 +112,93 @@ func TestRemoveAlbumsFromLibrary(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func testIDs(n int) []ID {
+	ids := make([]ID, n)
+	for i := range ids {
+		ids[i] = ID(fmt.Sprintf("id%03d", i))
+	}
+	return ids
+}
+
+func TestModifyLibraryBatched(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		modify func(*Client, ...ID) error
+	}{
+		{"add", http.MethodPut, func(c *Client, ids ...ID) error { return c.AddTracksToLibrary(context.Background(), ids...) }},
+		{"remove", http.MethodDelete, func(c *Client, ids ...ID) error { return c.RemoveTracksFromLibrary(context.Background(), ids...) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []ID
+			client, server, batches := batchServer(t, func(w http.ResponseWriter, r *http.Request, ids []string) {
+				if r.Method != tt.method || r.URL.Path != "/me/tracks" {
+					t.Errorf("Expected %s /me/tracks, got %s %s", tt.method, r.Method, r.URL.Path)
+				}
+				if r.URL.RawQuery != "" {
+					t.Errorf("Expected the IDs in the body only, got query %s", r.URL.RawQuery)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("Expected a JSON body, got %s", ct)
+				}
+				for _, id := range ids {
+					sent = append(sent, ID(id))
+				}
+			})
+			defer server.Close()
+
+			ids := testIDs(120)
+			if err := tt.modify(client, ids...); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*batches, []int{50, 50, 20}) {
+				t.Errorf("Expected batches of [50 50 20], got %v", *batches)
+			}
+			if !reflect.DeepEqual(sent, ids) {
+				t.Error("Not all IDs were sent in order")
+			}
+		})
+	}
+}
+
+func TestModifyLibraryNoIDs(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(*http.Request) {
+		t.Error("No request should have been sent")
+	})
+	defer server.Close()
+
+	if err := client.AddTracksToLibrary(context.Background()); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestUserHasTracksBatched(t *testing.T) {
+	client, server, batches := batchServer(t, func(w http.ResponseWriter, r *http.Request, ids []string) {
+		result := make([]bool, len(ids))
+		for i, id := range ids {
+			// every third track is saved
+			n, _ := strconv.Atoi(strings.TrimPrefix(id, "id"))
+			result[i] = n%3 == 0
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	defer server.Close()
+
+	contains, err := client.UserHasTracks(context.Background(), testIDs(101)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*batches, []int{50, 50, 1}) {
+		t.Errorf("Expected batches of [50 50 1], got %v", *batches)
+	}
+	if len(contains) != 101 {
+		t.Fatalf("Expected 101 results, got %d", len(contains))
+	}
+	for i, saved := range contains {
+		if saved != (i%3 == 0) {
+			t.Fatalf("Result %d out of order", i)
+		}
+	}
+}
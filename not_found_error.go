@@ -0,0 +1,16 @@
+package spotify
+
+// NotFoundError is returned when the Spotify Web API responds with
+// 404 Not Found, for example when an ID doesn't match any item.
+// It unwraps to the Error decoded from the response.
+type NotFoundError struct {
+	Err Error
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
@@ -128,6 +128,7 @@ type RecentlyPlayedOptions struct {
 }
 
 // PlayerDevices information about available devices for the current user.
+// A user without any available device gets an empty, non-nil slice.
 //
 // Requires the ScopeUserReadPlaybackState scope in order to read information
 func (c *Client) PlayerDevices(ctx context.Context) ([]PlayerDevice, error) {
@@ -140,6 +141,9 @@ func (c *Client) PlayerDevices(ctx context.Context) ([]PlayerDevice, error) {
 		return nil, err
 	}
 
+	if result.PlayerDevices == nil {
+		return []PlayerDevice{}, nil
+	}
 	return result.PlayerDevices, nil
 }
 
@@ -226,6 +230,27 @@ func (c *Client) PlayerRecentlyPlayedOpt(ctx context.Context, opt *RecentlyPlaye
 	return result.Items, nil
 }
 
+// RecentlyPlayed gets a cursor-based page of recently-played tracks for the
+// current user.  Use NextCursorPage to walk through the following pages.
+// This call requires ScopeUserReadRecentlyPlayed.
+//
+// Supported options: Limit, After, Before
+func (c *Client) RecentlyPlayed(ctx context.Context, opts ...RequestOption) (*RecentlyPlayedPage, error) {
+	spotifyURL := c.baseURL + "me/player/recently-played"
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result RecentlyPlayedPage
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // TransferPlayback transfers playback to a new device and determine if
 // it should start playing.
 //
@@ -387,7 +412,7 @@ func (c *Client) NextOpt(ctx context.Context, opt *PlayOptions) error {
 			spotifyURL += "?" + params
 		}
 	}
-	req, err := http.NewRequest(http.MethodPost, spotifyURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spotifyURL, nil)
 	if err != nil {
 		return err
 	}
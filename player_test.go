@@ -2,7 +2,11 @@ package spotify
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -16,7 +20,18 @@ func TestTransferPlaybackDeviceUnavailable(t *testing.T) {
 }
 
 func TestTransferPlayback(t *testing.T) {
-	client, server := testClientString(http.StatusNoContent, "")
+	client, server := testClientString(http.StatusNoContent, "", func(r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/me/player" {
+			t.Errorf("Expected PUT /me/player, got %s %s", r.Method, r.URL.Path)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(string(body)); got != `{"device_ids":["newdevice"],"play":true}` {
+			t.Errorf("Unexpected body: %s", got)
+		}
+	})
 	defer server.Close()
 
 	err := client.TransferPlayback(context.Background(), "newdevice", true)
@@ -66,6 +81,22 @@ func TestPlayerDevices(t *testing.T) {
 	}
 }
 
+func TestPlayerDevicesEmpty(t *testing.T) {
+	for _, body := range []string{`{"devices": []}`, `{"devices": null}`, `{}`} {
+		client, server := testClientString(http.StatusOK, body)
+
+		list, err := client.PlayerDevices(context.Background())
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: %v", body, err)
+			continue
+		}
+		if list == nil || len(list) != 0 {
+			t.Errorf("%s: expected an empty slice, got %#v", body, list)
+		}
+	}
+}
+
 func TestPlayerState(t *testing.T) {
 	client, server := testClientFile(http.StatusOK, "test_data/player_state.txt")
 	defer server.Close()
@@ -164,3 +195,95 @@ func TestPlayArgsError(t *testing.T) {
 		t.Error("Expected an error")
 	}
 }
+
+func TestPlaybackControls(t *testing.T) {
+	deviceID := ID("device")
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		call   func(*Client) error
+	}{
+		{"play", http.MethodPut, "/me/player/play", func(c *Client) error { return c.Play(context.Background()) }},
+		{"pause", http.MethodPut, "/me/player/pause", func(c *Client) error {
+			return c.PauseOpt(context.Background(), &PlayOptions{DeviceID: &deviceID})
+		}},
+		{"next", http.MethodPost, "/me/player/next", func(c *Client) error { return c.Next(context.Background()) }},
+		{"previous", http.MethodPost, "/me/player/previous", func(c *Client) error { return c.Previous(context.Background()) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := testClientString(http.StatusNoContent, "", func(r *http.Request) {
+				if r.Method != tt.method || r.URL.Path != tt.path {
+					t.Errorf("Expected %s %s, got %s %s", tt.method, tt.path, r.Method, r.URL.Path)
+				}
+			})
+			defer server.Close()
+
+			if err := tt.call(client); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPlayOptBody(t *testing.T) {
+	client, server := testClientString(http.StatusNoContent, "", func(r *http.Request) {
+		if id := r.URL.Query().Get("device_id"); id != "device" {
+			t.Errorf("Expected device_id device, got %q", id)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["context_uri"] != "spotify:album:1Je1IMUlBXcx1Fz0WE7oPT" {
+			t.Errorf("Unexpected context_uri: %v", body["context_uri"])
+		}
+		if offset, _ := body["offset"].(map[string]interface{}); offset["position"] != float64(5) {
+			t.Errorf("Unexpected offset: %v", body["offset"])
+		}
+		if body["position_ms"] != float64(1000) {
+			t.Errorf("Unexpected position_ms: %v", body["position_ms"])
+		}
+		if _, ok := body["uris"]; ok {
+			t.Error("uris should be omitted")
+		}
+	})
+	defer server.Close()
+
+	deviceID := ID("device")
+	contextURI := URI("spotify:album:1Je1IMUlBXcx1Fz0WE7oPT")
+	err := client.PlayOpt(context.Background(), &PlayOptions{
+		DeviceID:        &deviceID,
+		PlaybackContext: &contextURI,
+		PlaybackOffset:  &PlaybackOffset{Position: 5},
+		PositionMs:      1000,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlayNoActiveDevice(t *testing.T) {
+	json := `{
+		"error" : {
+			"status" : 404,
+			"message" : "Player command failed: No active device found",
+			"reason" : "NO_ACTIVE_DEVICE"
+		}
+	}`
+	client, server := testClientString(http.StatusNotFound, json)
+	defer server.Close()
+
+	err := client.Next(context.Background())
+	var serr Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("Expected a spotify.Error, got %v", err)
+	}
+	if serr.Reason != ReasonNoActiveDevice {
+		t.Errorf("Expected reason %s, got %q", ReasonNoActiveDevice, serr.Reason)
+	}
+	if serr.Status != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", serr.Status)
+	}
+}
@@ -179,6 +179,7 @@ func (c *Client) GetPlaylistTracks(
 	opts ...RequestOption,
 ) (*PlaylistTrackPage, error) {
 	spotifyURL := fmt.Sprintf("%splaylists/%s/tracks", c.baseURL, playlistID)
+	opts = append([]RequestOption{maxLimit(100)}, opts...)
 	if params := processOptions(opts...).urlParams.Encode(); params != "" {
 		spotifyURL += "?" + params
 	}
@@ -267,7 +268,7 @@ func (c *Client) GetPlaylistItems(ctx context.Context, playlistID ID, opts ...Re
 	spotifyURL := fmt.Sprintf("%splaylists/%s/tracks", c.baseURL, playlistID)
 
 	// Add default as the first option so it gets override by url.Values#Set
-	opts = append([]RequestOption{AdditionalTypes(EpisodeAdditionalType, TrackAdditionalType)}, opts...)
+	opts = append([]RequestOption{AdditionalTypes(EpisodeAdditionalType, TrackAdditionalType), maxLimit(100)}, opts...)
 
 	if params := processOptions(opts...).urlParams.Encode(); params != "" {
 		spotifyURL += "?" + params
@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Seeds contains IDs of artists, genres and/or tracks
@@ -74,15 +76,16 @@ func setTrackAttributesValues(trackAttributes *TrackAttributes, values url.Value
 // For artists and tracks that are very new or obscure
 // there might not be enough data to generate a list of tracks.
 //
-// Supported options: Limit, Country
+// Between 1 and MaxNumberOfSeeds seeds, combined across artists, tracks and
+// genres, must be provided; other counts are rejected before any request is
+// sent.  Only the track attributes that have been set are sent.
+//
+// Supported options: Limit, Market
 func (c *Client) GetRecommendations(ctx context.Context, seeds Seeds, trackAttributes *TrackAttributes, opts ...RequestOption) (*Recommendations, error) {
-	v := processOptions(opts...).urlParams
+	v := processOptions(append([]RequestOption{maxLimit(100)}, opts...)...).urlParams
 
-	if seeds.count() == 0 {
-		return nil, fmt.Errorf("spotify: at least one seed is required")
-	}
-	if seeds.count() > MaxNumberOfSeeds {
-		return nil, fmt.Errorf("spotify: exceeded maximum of %d seeds", MaxNumberOfSeeds)
+	if n := seeds.count(); n < 1 || n > MaxNumberOfSeeds {
+		return nil, fmt.Errorf("spotify: recommendations require between 1 and %d seeds, got %d", MaxNumberOfSeeds, n)
 	}
 
 	setSeedValues(seeds, v)
@@ -101,15 +104,118 @@ func (c *Client) GetRecommendations(ctx context.Context, seeds Seeds, trackAttri
 
 // GetAvailableGenreSeeds retrieves a list of available genres seed parameter values for
 // recommendations.
+//
+// If the client was configured with WithGenreSeedCache, the list is only
+// fetched once per TTL and concurrent callers share a single request.  A
+// caller whose context is cancelled stops waiting for that request without
+// cancelling it for the others; the request is cancelled once every caller
+// waiting for it has given up.
 func (c *Client) GetAvailableGenreSeeds(ctx context.Context) ([]string, error) {
+	if c.genreSeedTTL <= 0 {
+		return c.fetchAvailableGenreSeeds(ctx)
+	}
+	return c.genreSeeds.get(ctx, c)
+}
+
+func (c *Client) fetchAvailableGenreSeeds(ctx context.Context) ([]string, error) {
 	spotifyURL := c.baseURL + "recommendations/available-genre-seeds"
 
-	genreSeeds := make(map[string][]string)
+	var result struct {
+		Genres []string `json:"genres"`
+	}
 
-	err := c.get(ctx, spotifyURL, &genreSeeds)
+	err := c.get(ctx, spotifyURL, &result)
 	if err != nil {
 		return nil, err
 	}
 
-	return genreSeeds["genres"], nil
+	return result.Genres, nil
+}
+
+// WithGenreSeedCache configures the client to memoize the result of
+// GetAvailableGenreSeeds for ttl, as the list of genres rarely changes.
+// A ttl of 0, the default, disables the cache.
+func WithGenreSeedCache(ttl time.Duration) ClientOption {
+	return func(client *Client) {
+		client.genreSeedTTL = ttl
+	}
+}
+
+// genreSeedCache memoizes the available genre seeds.  Callers that miss the
+// cache while it is being filled wait for the in-flight request rather than
+// sending their own.
+type genreSeedCache struct {
+	mu      sync.Mutex
+	genres  []string
+	expires time.Time
+	call    *genreSeedCall
+}
+
+// genreSeedCall is an in-flight request for the available genre seeds.
+type genreSeedCall struct {
+	done    chan struct{}
+	genres  []string
+	err     error
+	waiters int // guarded by genreSeedCache.mu
+	cancel  context.CancelFunc
+}
+
+func (g *genreSeedCache) get(ctx context.Context, c *Client) ([]string, error) {
+	g.mu.Lock()
+	if g.genres != nil && c.clk().Now().Before(g.expires) {
+		genres := g.genres
+		g.mu.Unlock()
+		return append([]string(nil), genres...), nil
+	}
+	call := g.call
+	if call == nil {
+		// The request is shared with every caller that joins it, so it
+		// must not be cancelled along with the caller that started it,
+		// only once all of them have given up.
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &genreSeedCall{done: make(chan struct{}), cancel: cancel}
+		g.call = call
+		go g.fill(fetchCtx, c, call)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return append([]string(nil), call.genres...), call.err
+	case <-ctx.Done():
+		g.leave(call)
+		return nil, ctx.Err()
+	}
+}
+
+// leave cancels call when the last caller waiting for it gives up, so that
+// a stalled request doesn't outlive everyone interested in its result.
+func (g *genreSeedCache) leave(call *genreSeedCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	if g.call == call {
+		g.call = nil
+	}
+	call.cancel()
+}
+
+func (g *genreSeedCache) fill(ctx context.Context, c *Client, call *genreSeedCall) {
+	defer call.cancel()
+	call.genres, call.err = c.fetchAvailableGenreSeeds(ctx)
+
+	g.mu.Lock()
+	if g.call == call {
+		g.call = nil
+	}
+	if call.err == nil {
+		g.genres = call.genres
+		g.expires = c.clk().Now().Add(c.genreSeedTTL)
+	}
+	g.mu.Unlock()
+	close(call.done)
 }
@@ -2,8 +2,15 @@ package spotify
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestGetRecommendations(t *testing.T) {
@@ -70,3 +77,225 @@ func TestSetEmptyTrackAttributesValues(t *testing.T) {
 		t.Errorf("Expected track attributes values to be empty but got %s", actualValues)
 	}
 }
+
+func TestGetRecommendationsSeedCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		seeds Seeds
+		valid bool
+	}{
+		{"no seeds", Seeds{}, false},
+		{"one genre", Seeds{Genres: []string{"classical"}}, true},
+		{"five combined", Seeds{
+			Artists: []ID{"4NHQUGzhtTLFvgF5SZesLK", "5PHQUGzhtTUIvgF5SZesGY"},
+			Tracks:  []ID{"0c6xIDDpzE81m2q797ordA"},
+			Genres:  []string{"classical", "country"},
+		}, true},
+		{"six combined", Seeds{
+			Artists: []ID{"4NHQUGzhtTLFvgF5SZesLK", "5PHQUGzhtTUIvgF5SZesGY"},
+			Tracks:  []ID{"0c6xIDDpzE81m2q797ordA", "3n3Ppam7vgaVa1iaRUc9Lp"},
+			Genres:  []string{"classical", "country"},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := false
+			client, server := testClientFile(http.StatusOK, "test_data/recommendations.txt", func(*http.Request) {
+				requested = true
+			})
+			defer server.Close()
+
+			_, err := client.GetRecommendations(context.Background(), tt.seeds, nil)
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected a seed count error")
+			}
+			if requested != tt.valid {
+				t.Errorf("Expected a request to be sent: %v, got %v", tt.valid, requested)
+			}
+		})
+	}
+}
+
+func TestGetRecommendationsQuery(t *testing.T) {
+	expected := "limit=100&market=ES&max_tempo=140&min_danceability=0.5&seed_tracks=0c6xIDDpzE81m2q797ordA&target_energy=0.8"
+	client, server := testClientFile(http.StatusOK, "test_data/recommendations.txt", func(r *http.Request) {
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query %s, got %s", expected, r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+
+	seeds := Seeds{Tracks: []ID{"0c6xIDDpzE81m2q797ordA"}}
+	ta := NewTrackAttributes().
+		MaxTempo(140).
+		MinDanceability(0.5).
+		TargetEnergy(0.8)
+	_, err := client.GetRecommendations(context.Background(), seeds, ta, Market("ES"), Limit(150))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetAvailableGenreSeeds(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"genres": ["acoustic", "afrobeat", "alt-rock"]}`)
+	defer server.Close()
+
+	genres, err := client.GetAvailableGenreSeeds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(genres) != 3 || genres[0] != "acoustic" || genres[2] != "alt-rock" {
+		t.Errorf("Unexpected genres: %v", genres)
+	}
+}
+
+func TestGenreSeedCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`{"genres": ["acoustic", "afrobeat"]}`))
+	}))
+	defer server.Close()
+	clk := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", clock: clk}
+	WithGenreSeedCache(time.Hour)(client)
+
+	for i := 0; i < 3; i++ {
+		genres, err := client.GetAvailableGenreSeeds(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(genres) != 2 || genres[1] != "afrobeat" {
+			t.Fatalf("Unexpected genres: %v", genres)
+		}
+		genres[0] = "modified by the caller"
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("Expected a single request within the TTL, got %d", n)
+	}
+
+	_ = clk.Sleep(context.Background(), time.Hour)
+	genres, err := client.GetAvailableGenreSeeds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genres[0] != "acoustic" {
+		t.Errorf("The cached genres should not be modifiable by callers, got %v", genres)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected the genres to be fetched again after the TTL, got %d requests", n)
+	}
+}
+
+func TestGenreSeedCacheSingleFlight(t *testing.T) {
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	server := blockingServer(started, release)
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", genreSeedTTL: time.Hour}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			genres, err := client.GetAvailableGenreSeeds(context.Background())
+			if err == nil && (len(genres) != 1 || genres[0] != "rock") {
+				err = fmt.Errorf("unexpected genres: %v", genres)
+			}
+			errs <- err
+		}()
+	}
+
+	<-started
+	// give the other callers a chance to pile up behind the first request
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if n := len(started); n != 0 {
+		t.Errorf("Expected a single request, got %d more", n)
+	}
+}
+
+func TestGenreSeedCacheLeaderCancelled(t *testing.T) {
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	server := blockingServer(started, release)
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", genreSeedTTL: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := client.GetAvailableGenreSeeds(ctx)
+		leader <- err
+	}()
+	<-started
+
+	follower := make(chan error, 1)
+	go func() {
+		genres, err := client.GetAvailableGenreSeeds(context.Background())
+		if err == nil && (len(genres) != 1 || genres[0] != "rock") {
+			err = fmt.Errorf("unexpected genres: %v", genres)
+		}
+		follower <- err
+	}()
+	// give the follower a chance to join the leader's request
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-leader; err != context.Canceled {
+		t.Errorf("Expected the leader to be cancelled, got %v", err)
+	}
+	close(release)
+	if err := <-follower; err != nil {
+		t.Errorf("The follower should not be affected by the leader's cancellation: %v", err)
+	}
+	if n := len(started); n != 0 {
+		t.Errorf("Expected a single request, got %d more", n)
+	}
+}
+
+func TestGenreSeedCacheAllWaitersCancelled(t *testing.T) {
+	aborted := make(chan struct{}, 1)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// stall until the client gives up
+			<-r.Context().Done()
+			aborted <- struct{}{}
+			return
+		}
+		_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+	}))
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", genreSeedTTL: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetAvailableGenreSeeds(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the caller to time out, got %v", err)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the stalled request to be cancelled")
+	}
+
+	genres, err := client.GetAvailableGenreSeeds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(genres) != 1 || genres[0] != "rock" {
+		t.Errorf("Unexpected genres %v", genres)
+	}
+}
@@ -0,0 +1,105 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// offline is a transport failing every request, to make sure replays never
+// reach the network.
+func offline(t *testing.T) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("Unexpected request to %s", req.URL)
+		return nil, errors.New("offline")
+	})
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	c, s := testClientFile(http.StatusOK, "test_data/new_releases.txt")
+	defer s.Close()
+
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette)
+	c.http = &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"}),
+	}}
+	WithRequestRecorder(recorder)(c)
+
+	recorded, err := c.NewReleases(context.Background(), Country(CountryBrazil), Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	if strings.Contains(cassette.String(), "secret-token") {
+		t.Error("The cassette should not contain the access token")
+	}
+	interactions := recorder.Interactions()
+	if len(interactions) != 1 {
+		t.Fatalf("Expected 1 interaction, got %d", len(interactions))
+	}
+	if i := interactions[0]; i.Method != http.MethodGet || i.Status != http.StatusOK || !strings.HasSuffix(i.URL, "/browse/new-releases?country=BR&limit=2") {
+		t.Errorf("Unexpected interaction %s %s: %d", i.Method, i.URL, i.Status)
+	}
+
+	replayer, err := NewReplayer(&cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := New(&http.Client{Transport: offline(t)}, WithBaseURL(c.baseURL), WithRequestRecorder(replayer))
+	for n := 0; n < 2; n++ {
+		replayed, err := replay.NewReleases(context.Background(), Country(CountryBrazil), Limit(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(replayed, recorded) {
+			t.Errorf("Replay %d differs from the recorded response", n)
+		}
+	}
+	if l := len(replayer.Interactions()); l != 2 {
+		t.Errorf("Expected the replayer to capture 2 requests, got %d", l)
+	}
+}
+
+func TestRecorderReplaysRetries(t *testing.T) {
+	cassette := `{"method": "GET", "url": "https://api.spotify.com/v1/recommendations/available-genre-seeds", "status": 429, "response_header": {"Retry-After": ["1"]}, "response_body": ""}
+{"method": "GET", "url": "https://api.spotify.com/v1/recommendations/available-genre-seeds", "status": 200, "response_body": "{\"genres\": [\"rock\"]}"}
+`
+	replayer, err := NewReplayer(strings.NewReader(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(&http.Client{Transport: offline(t)}, WithRetry(true), WithRequestRecorder(replayer))
+	c.clock = &fakeClock{}
+
+	genres, err := c.GetAvailableGenreSeeds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(genres) != 1 || genres[0] != "rock" {
+		t.Errorf("Unexpected genres %v", genres)
+	}
+	if l := len(replayer.Interactions()); l != 2 {
+		t.Errorf("Expected 2 attempts, got %d", l)
+	}
+}
+
+func TestRecorderReplayMissing(t *testing.T) {
+	replayer, err := NewReplayer(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(&http.Client{Transport: offline(t)}, WithRequestRecorder(replayer))
+
+	_, err = c.NewReleases(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no recorded response for GET /v1/browse/new-releases") {
+		t.Errorf("Expected a missing recording error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package spotify
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// spotifyRequestIDHeader is the header the Spotify Web API uses to identify
+// a request, should you need to report it to Spotify support.
+const spotifyRequestIDHeader = "X-Request-Id"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying id.  Clients configured
+// with WithRequestIDHeader send id instead of generating a new one for the
+// requests made with the returned context, so that calls to Spotify can be
+// traced back to the operation that triggered them.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+func correlationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithRequestIDHeader configures the client to identify every request with
+// the header name.  The value is taken from the request's context when it
+// was set with WithCorrelationID, and is a random UUID otherwise.  That ID
+// is included in the "spotify response" and "will retry..." log records,
+// along with the request ID returned by Spotify which is logged whether or
+// not this option is set.
+func WithRequestIDHeader(name string) ClientOption {
+	return func(client *Client) {
+		client.requestIDHeader = name
+	}
+}
+
+// setRequestID sets the configured request ID header on req and returns its
+// value, or the empty string when the client doesn't send request IDs.
+func (c *Client) setRequestID(req *http.Request) (string, error) {
+	if c.requestIDHeader == "" {
+		return "", nil
+	}
+	id, ok := correlationID(req.Context())
+	if !ok {
+		var err error
+		if id, err = newUUID(); err != nil {
+			return "", err
+		}
+	}
+	req.Header.Set(c.requestIDHeader, id)
+	return id, nil
+}
+
+// responseRequestID returns the request ID Spotify assigned to resp, if any.
+// A value equal to sent, the ID the client sent itself, is only an echo of
+// it and is ignored.
+func responseRequestID(resp *http.Response, sent string) string {
+	if resp == nil {
+		return ""
+	}
+	if id := resp.Header.Get(spotifyRequestIDHeader); id != sent {
+		return id
+	}
+	return ""
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("spotify: generating request ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,146 @@
+package spotify
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestIDHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string // empty for a generated ID
+	}{
+		{"generated", context.Background(), ""},
+		{"correlation ID", WithCorrelationID(context.Background(), "checkout-42"), "checkout-42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = r.Header.Get("X-Correlation-Id")
+				w.Header().Set("X-Request-Id", "spotify-123")
+				_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+			}))
+			defer server.Close()
+			h := newRecordingHandler()
+			c := New(http.DefaultClient, WithBaseURL(server.URL+"/"),
+				WithLogger(slog.New(h)), WithRequestIDHeader("X-Correlation-Id"))
+
+			if _, err := c.GetAvailableGenreSeeds(tt.ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want != "" && sent != tt.want {
+				t.Errorf("Expected header %q, got %q", tt.want, sent)
+			}
+			if tt.want == "" && !uuidPattern.MatchString(sent) {
+				t.Errorf("Expected a generated UUID, got %q", sent)
+			}
+			attrs, ok := h.find("spotify response")
+			if !ok {
+				t.Fatal("Expected a spotify response log record")
+			}
+			if id := attrs["requestID"].String(); id != sent {
+				t.Errorf("Expected requestID %q in the log, got %q", sent, id)
+			}
+			if id := attrs["spotifyRequestID"].String(); id != "spotify-123" {
+				t.Errorf("Expected spotifyRequestID spotify-123 in the log, got %q", id)
+			}
+		})
+	}
+}
+
+func TestRequestIDHeaderDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get("X-Request-Id"); id != "" {
+			t.Errorf("Expected no request ID, got %q", id)
+		}
+		w.Header().Set("X-Request-Id", "spotify-123")
+		_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+	}))
+	defer server.Close()
+	h := newRecordingHandler()
+	c := New(http.DefaultClient, WithBaseURL(server.URL+"/"), WithLogger(slog.New(h)))
+
+	if _, err := c.GetAvailableGenreSeeds(WithCorrelationID(context.Background(), "ignored")); err != nil {
+		t.Fatal(err)
+	}
+	attrs, _ := h.find("spotify response")
+	if _, ok := attrs["requestID"]; ok {
+		t.Error("Expected no requestID in the log")
+	}
+	if id := attrs["spotifyRequestID"].String(); id != "spotify-123" {
+		t.Errorf("Expected spotifyRequestID spotify-123 in the log, got %q", id)
+	}
+}
+
+func TestRequestIDHeaderEchoed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxy echoing the client's own header
+		w.Header().Set("X-Request-Id", r.Header.Get("X-Request-Id"))
+		_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+	}))
+	defer server.Close()
+	h := newRecordingHandler()
+	c := New(http.DefaultClient, WithBaseURL(server.URL+"/"),
+		WithLogger(slog.New(h)), WithRequestIDHeader("X-Request-Id"))
+
+	if _, err := c.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	attrs, _ := h.find("spotify response")
+	if _, ok := attrs["spotifyRequestID"]; ok {
+		t.Errorf("Expected no spotifyRequestID for an echoed header, got %q", attrs["spotifyRequestID"])
+	}
+	if !uuidPattern.MatchString(attrs["requestID"].String()) {
+		t.Errorf("Expected the generated requestID in the log, got %q", attrs["requestID"])
+	}
+}
+
+func TestRequestIDRetryLogged(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Request-Id", "spotify-123")
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(rateLimitExceededStatusCode)
+			return
+		}
+		_, _ = io.WriteString(w, `{"genres": ["rock"]}`)
+	}))
+	defer server.Close()
+	h := newRecordingHandler()
+	c := New(http.DefaultClient, WithBaseURL(server.URL+"/"), WithRetry(true),
+		WithLogger(slog.New(h)), WithRequestIDHeader("X-Correlation-Id"))
+	c.clock = &fakeClock{}
+
+	if _, err := c.GetAvailableGenreSeeds(WithCorrelationID(context.Background(), "checkout-42")); err != nil {
+		t.Fatal(err)
+	}
+	attrs, ok := h.find("will retry...")
+	if !ok {
+		t.Fatal("Expected a will retry... log record")
+	}
+	if id := attrs["requestID"].String(); id != "checkout-42" {
+		t.Errorf("Expected requestID checkout-42 in the log, got %q", id)
+	}
+	if id := attrs["spotifyRequestID"].String(); id != "spotify-123" {
+		t.Errorf("Expected spotifyRequestID spotify-123 in the log, got %q", id)
+	}
+}
+
+func TestCloneKeepsRequestIDHeader(t *testing.T) {
+	c := New(http.DefaultClient, WithRequestIDHeader("X-Correlation-Id"))
+	if clone := c.Clone(); clone.requestIDHeader != "X-Correlation-Id" {
+		t.Errorf("Expected the clone to keep the request ID header, got %q", clone.requestIDHeader)
+	}
+}
@@ -6,31 +6,68 @@ import (
 	"strings"
 )
 
+// RequestOption sets an optional query parameter on a request.  Options are
+// applied in the order they are given: when several options set the same
+// parameter, the last one wins.  Parameters are always encoded sorted by key,
+// so the resulting query string doesn't depend on the order of the options.
 type RequestOption func(*requestOptions)
 
 type requestOptions struct {
 	urlParams url.Values
+	// maxLimit is the largest limit accepted by the endpoint the options
+	// are processed for.
+	maxLimit int
+	// clampLimit is set when the limit was set by Limit, rather than by
+	// QueryParam, and must be clamped to maxLimit.
+	clampLimit bool
 }
 
-// Limit sets the number of entries that a request should return
+// defaultMaxLimit is the largest limit accepted by most paged endpoints.
+const defaultMaxLimit = 50
+
+// Limit sets the number of entries that a request should return.
+// Values outside of the range accepted by the endpoint are clamped to it:
+// most endpoints accept 1 to 50 entries, some (such as playlist items and
+// recommendations) accept up to 100.
 func Limit(amount int) RequestOption {
 	return func(o *requestOptions) {
 		o.urlParams.Set("limit", strconv.Itoa(amount))
+		o.clampLimit = true
+	}
+}
+
+// maxLimit overrides the largest limit accepted by an endpoint.
+func maxLimit(amount int) RequestOption {
+	return func(o *requestOptions) {
+		o.maxLimit = amount
 	}
 }
 
-// Market enables track re-linking
+// Market enables track re-linking.  The code is an ISO 3166-1 alpha-2
+// country code, or MarketFromToken to use the country of the current user.
+// Endpoints accept either a market or a country, so Market replaces any
+// Country supplied before it.
 func Market(code string) RequestOption {
 	return func(o *requestOptions) {
+		o.urlParams.Del("country")
 		o.urlParams.Set("market", code)
 	}
 }
 
+// UserMarket limits results to content playable in the country associated
+// with the user's access token.  It is equivalent to Market(MarketFromToken).
+func UserMarket() RequestOption {
+	return Market(MarketFromToken)
+}
+
 // Country enables a specific region to be specified for region-specific suggestions e.g popular playlists
 // The Country option takes an ISO 3166-1 alpha-2 country code.  It can be
 // used to ensure that the category exists for a particular country.
+// Endpoints accept either a market or a country, so Country replaces any
+// Market supplied before it.
 func Country(code string) RequestOption {
 	return func(o *requestOptions) {
+		o.urlParams.Del("market")
 		o.urlParams.Set("country", code)
 	}
 }
@@ -53,6 +90,20 @@ func Offset(amount int) RequestOption {
 	}
 }
 
+// QueryParam sets an arbitrary query parameter.  It is an escape hatch for
+// parameters supported by the Web API that don't have a dedicated option;
+// it replaces any value previously set for key.  The value is sent as is:
+// unlike Limit, QueryParam("limit", ...) isn't clamped to the range accepted
+// by the endpoint.
+func QueryParam(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.urlParams.Set(key, value)
+		if key == "limit" {
+			o.clampLimit = false
+		}
+	}
+}
+
 // Timestamp in ISO 8601 format (yyyy-MM-ddTHH:mm:ss).
 // use this parameter to specify the user's local time to
 // get results tailored for that specific date and time
@@ -65,12 +116,25 @@ func Timestamp(ts string) RequestOption {
 }
 
 // After is the last ID retrieved from the previous request. This allows pagination.
+// Endpoints accept either an after or a before cursor, so After replaces any
+// Before supplied before it.
 func After(after string) RequestOption {
 	return func(o *requestOptions) {
+		o.urlParams.Del("before")
 		o.urlParams.Set("after", after)
 	}
 }
 
+// Before is the cursor of the first item retrieved by the previous request,
+// used to page backwards through cursor-based results such as the
+// recently-played tracks.  Before replaces any After supplied before it.
+func Before(before string) RequestOption {
+	return func(o *requestOptions) {
+		o.urlParams.Del("after")
+		o.urlParams.Set("before", before)
+	}
+}
+
 // Fields is a comma-separated list of the fields to return.
 // See the JSON tags on the FullPlaylist struct for valid field options.
 // For example, to get just the playlist's description and URI:
@@ -136,10 +200,22 @@ func AdditionalTypes(types ...AdditionalType) RequestOption {
 func processOptions(options ...RequestOption) requestOptions {
 	o := requestOptions{
 		urlParams: url.Values{},
+		maxLimit:  defaultMaxLimit,
 	}
 	for _, opt := range options {
 		opt(&o)
 	}
 
+	if raw := o.urlParams.Get("limit"); raw != "" && o.clampLimit {
+		limit, _ := strconv.Atoi(raw)
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > o.maxLimit {
+			limit = o.maxLimit
+		}
+		o.urlParams.Set("limit", strconv.Itoa(limit))
+	}
+
 	return o
 }
@@ -9,7 +9,6 @@ func TestOptions(t *testing.T) {
 
 	resultSet := processOptions(
 		After("example_id"),
-		Country(CountryUnitedKingdom),
 		Limit(13),
 		Locale("en_GB"),
 		Market(CountryArgentina),
@@ -18,9 +17,108 @@ func TestOptions(t *testing.T) {
 		Timestamp("2000-11-02T13:37:00"),
 	)
 
-	expected := "after=example_id&country=GB&limit=13&locale=en_GB&market=AR&offset=1&time_range=long&timestamp=2000-11-02T13%3A37%3A00"
+	expected := "after=example_id&limit=13&locale=en_GB&market=AR&offset=1&time_range=long&timestamp=2000-11-02T13%3A37%3A00"
 	actual := resultSet.urlParams.Encode()
 	if actual != expected {
 		t.Errorf("Expected '%v', got '%v'", expected, actual)
 	}
 }
+
+func TestMarketOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []RequestOption
+		expected string
+	}{
+		{"market", []RequestOption{Market(CountryArgentina)}, "market=AR"},
+		{"user market", []RequestOption{UserMarket()}, "market=from_token"},
+		{"country", []RequestOption{Country(CountryUnitedKingdom)}, "country=GB"},
+		{"market replaces country", []RequestOption{Country(CountryUnitedKingdom), Market(CountryArgentina)}, "market=AR"},
+		{"country replaces market", []RequestOption{UserMarket(), Country(CountryUnitedKingdom)}, "country=GB"},
+		{"last market wins", []RequestOption{Market(CountryArgentina), UserMarket()}, "market=from_token"},
+	}
+	for _, tt := range tests {
+		if actual := processOptions(tt.opts...).urlParams.Encode(); actual != tt.expected {
+			t.Errorf("%s: expected '%v', got '%v'", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestLimitBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []RequestOption
+		expected string
+	}{
+		{"within bounds", []RequestOption{Limit(20)}, "limit=20"},
+		{"at maximum", []RequestOption{Limit(50)}, "limit=50"},
+		{"above maximum", []RequestOption{Limit(51)}, "limit=50"},
+		{"zero", []RequestOption{Limit(0)}, "limit=1"},
+		{"negative", []RequestOption{Limit(-5)}, "limit=1"},
+		{"raised maximum", []RequestOption{maxLimit(100), Limit(100)}, "limit=100"},
+		{"above raised maximum", []RequestOption{Limit(500), maxLimit(100)}, "limit=100"},
+		{"no limit", []RequestOption{Offset(10)}, "offset=10"},
+		{"query param limit", []RequestOption{QueryParam("limit", "200")}, "limit=200"},
+		{"non-numeric query param limit", []RequestOption{QueryParam("limit", "all")}, "limit=all"},
+		{"query param after limit", []RequestOption{Limit(10), QueryParam("limit", "200")}, "limit=200"},
+		{"limit after query param", []RequestOption{QueryParam("limit", "200"), Limit(500)}, "limit=50"},
+	}
+	for _, tt := range tests {
+		if actual := processOptions(tt.opts...).urlParams.Encode(); actual != tt.expected {
+			t.Errorf("%s: expected '%v', got '%v'", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestOptionsMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []RequestOption
+		expected string
+	}{
+		{"none", nil, ""},
+		{"country", []RequestOption{Country(CountryGermany)}, "country=DE"},
+		{"locale", []RequestOption{Locale("es_MX")}, "locale=es_MX"},
+		{"limit and offset", []RequestOption{Offset(40), Limit(20)}, "limit=20&offset=40"},
+		{"repeated limit", []RequestOption{Limit(10), Limit(20)}, "limit=20"},
+		{"repeated offset", []RequestOption{Offset(5), Offset(0)}, "offset=0"},
+		{"repeated locale", []RequestOption{Locale("es_MX"), Locale("en_GB")}, "locale=en_GB"},
+		{"order independent", []RequestOption{Locale("en_GB"), Country(CountryUnitedKingdom), Offset(3)}, "country=GB&locale=en_GB&offset=3"},
+		{"query param", []RequestOption{QueryParam("include_external", "audio")}, "include_external=audio"},
+		{"query param escaped", []RequestOption{QueryParam("q", "a b&c")}, "q=a+b%26c"},
+		{"query param overrides", []RequestOption{Limit(10), QueryParam("limit", "30")}, "limit=30"},
+		{"option overrides query param", []RequestOption{QueryParam("country", "SE"), Country(CountryFrance)}, "country=FR"},
+		{"repeated query param", []RequestOption{QueryParam("x", "1"), QueryParam("x", "2")}, "x=2"},
+	}
+	for _, tt := range tests {
+		if actual := processOptions(tt.opts...).urlParams.Encode(); actual != tt.expected {
+			t.Errorf("%s: expected '%v', got '%v'", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestCursorOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []RequestOption
+		expected string
+	}{
+		{"after", []RequestOption{After("1495915674721")}, "after=1495915674721"},
+		{"before", []RequestOption{Before("1495915183103"), Limit(2)}, "before=1495915183103&limit=2"},
+		{"before replaces after", []RequestOption{After("1"), Before("2")}, "before=2"},
+		{"after replaces before", []RequestOption{Before("2"), After("1")}, "after=1"},
+	}
+	for _, tt := range tests {
+		if actual := processOptions(tt.opts...).urlParams.Encode(); actual != tt.expected {
+			t.Errorf("%s: expected '%v', got '%v'", tt.name, tt.expected, actual)
+		}
+	}
+}
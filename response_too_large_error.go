@@ -0,0 +1,51 @@
+package spotify
+
+import (
+	"fmt"
+	"io"
+)
+
+// defaultMaxResponseBytes is the largest response body a client created
+// with New reads unless configured otherwise with WithMaxResponseBytes.
+const defaultMaxResponseBytes = 10 << 20
+
+// ResponseTooLargeError is returned when a response body is larger than
+// the limit configured with WithMaxResponseBytes.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("spotify: response body exceeds the %d bytes limit", e.Limit)
+}
+
+// limitedBody is a response body that fails with a ResponseTooLargeError
+// once more than limit bytes have been read from it.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// probe for one more byte to tell a body of exactly limit
+		// bytes from an oversized one
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseTooLargeError{Limit: b.limit}
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
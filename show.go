@@ -2,6 +2,7 @@ package spotify
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -78,6 +79,78 @@ type SimpleShow struct {
 	URI URI `json:"uri"`
 }
 
+// SimpleEpisode contains basic data about a podcast episode.  It holds the
+// fields of EpisodePage, used by SimpleEpisodePage and playlist items, but
+// for Show.
+type SimpleEpisode struct {
+	// A URL to a 30 second preview (MP3 format) of the episode.
+	AudioPreviewURL string `json:"audio_preview_url"`
+
+	// A description of the episode.
+	Description string `json:"description"`
+
+	// The episode length in milliseconds.
+	Duration_ms int `json:"duration_ms"`
+
+	// Whether or not the episode has explicit content
+	// (true = yes it does; false = no it does not OR unknown).
+	Explicit bool `json:"explicit"`
+
+	// 	External URLs for this episode.
+	ExternalURLs map[string]string `json:"external_urls"`
+
+	// A link to the Web API endpoint providing full details of the episode.
+	Href string `json:"href"`
+
+	// The Spotify ID for the episode.
+	ID ID `json:"id"`
+
+	// The cover art for the episode in various sizes, widest first.
+	Images []Image `json:"images"`
+
+	// True if the episode is hosted outside of Spotify’s CDN.
+	IsExternallyHosted bool `json:"is_externally_hosted"`
+
+	// True if the episode is playable in the given market.
+	// Otherwise false.
+	IsPlayable bool `json:"is_playable"`
+
+	// A list of the languages used in the episode, identified by their ISO 639 code.
+	Languages []string `json:"languages"`
+
+	// The name of the episode.
+	Name string `json:"name"`
+
+	// The date the episode was first released, for example
+	// "1981-12-15". Depending on the precision, it might
+	// be shown as "1981" or "1981-12".
+	ReleaseDate string `json:"release_date"`
+
+	// The precision with which release_date value is known:
+	// "year", "month", or "day".
+	ReleaseDatePrecision string `json:"release_date_precision"`
+
+	// The user’s most recent position in the episode. Set if the
+	// supplied access token is a user token and has the scope
+	// user-read-playback-position.
+	ResumePoint ResumePointObject `json:"resume_point"`
+
+	// The object type: "episode".
+	Type string `json:"type"`
+
+	// The Spotify URI for the episode.
+	URI URI `json:"uri"`
+}
+
+// FullEpisode provides extra episode data in addition to the data
+// provided by SimpleEpisode.
+type FullEpisode struct {
+	SimpleEpisode
+
+	// The show on which the episode belongs.
+	Show SimpleShow `json:"show"`
+}
+
 type EpisodePage struct {
 	// A URL to a 30 second preview (MP3 format) of the episode.
 	AudioPreviewURL string `json:"audio_preview_url"`
@@ -149,6 +222,25 @@ type ResumePointObject struct {
 	ResumePositionMs int `json:"resume_position_ms"`
 }
 
+// ReleaseDateTime converts the episode's ReleaseDate to a time.TimeValue.
+// All of the fields in the result may not be valid.  For example, if
+// ReleaseDatePrecision is "month", then only the month and year
+// (but not the day) of the result are valid.
+func (e *SimpleEpisode) ReleaseDateTime() time.Time {
+	if e.ReleaseDatePrecision == "day" {
+		result, _ := time.Parse(DateLayout, e.ReleaseDate)
+		return result
+	}
+	if e.ReleaseDatePrecision == "month" {
+		ym := strings.Split(e.ReleaseDate, "-")
+		year, _ := strconv.Atoi(ym[0])
+		month, _ := strconv.Atoi(ym[1])
+		return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	}
+	year, _ := strconv.Atoi(e.ReleaseDate)
+	return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
 // ReleaseDateTime converts the show's ReleaseDate to a time.TimeValue.
 // All of the fields in the result may not be valid.  For example, if
 // ReleaseDatePrecision is "month", then only the month and year
@@ -168,7 +260,10 @@ func (e *EpisodePage) ReleaseDateTime() time.Time {
 	return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
 }
 
-// GetShow retrieves information about a specific show.
+// GetShow retrieves information about a specific show.  Episodes that are
+// unavailable in the market are returned as null by the API when no market
+// is given; they show up as zero-valued entries (with an empty ID) in the
+// show's episode page.
 // API reference: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-a-show
 // Supported options: Market
 func (c *Client) GetShow(ctx context.Context, id ID, opts ...RequestOption) (*FullShow, error) {
@@ -187,7 +282,9 @@ func (c *Client) GetShow(ctx context.Context, id ID, opts ...RequestOption) (*Fu
 	return &result, nil
 }
 
-// GetShowEpisodes retrieves paginated episode information about a specific show..
+// GetShowEpisodes retrieves paginated episode information about a specific show.
+// As with GetShow, episodes unavailable in the market appear as zero-valued
+// entries (with an empty ID) when no market is given.
 // API reference: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-a-shows-episodes
 // Supported options: Market, Limit, Offset
 func (c *Client) GetShowEpisodes(ctx context.Context,  id string, opts ...RequestOption) (*SimpleEpisodePage, error) {
@@ -205,3 +302,83 @@ func (c *Client) GetShowEpisodes(ctx context.Context,  id string, opts ...Reques
 
 	return &result, nil
 }
+
+// GetShows retrieves information about several shows.  The API supports up
+// to 50 shows in a single call; larger sets of IDs are split into several
+// calls transparently.  Shows are returned in the order requested.  If a
+// show is not found or isn't available in the market, that position in the
+// result will be nil.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-multiple-shows
+// Supported options: Market
+func (c *Client) GetShows(ctx context.Context, ids []ID, opts ...RequestOption) ([]*SimpleShow, error) {
+	params := processOptions(opts...).urlParams
+
+	shows := make([]*SimpleShow, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, 50) {
+		params.Set("ids", strings.Join(toStringSlice(chunk), ","))
+		spotifyURL := fmt.Sprintf("%sshows?%s", c.baseURL, params.Encode())
+
+		var s struct {
+			Shows []*SimpleShow `json:"shows"`
+		}
+
+		err := c.get(ctx, spotifyURL, &s)
+		if err != nil {
+			return nil, err
+		}
+
+		shows = append(shows, s.Shows...)
+	}
+
+	return shows, nil
+}
+
+// GetEpisode retrieves information about a specific episode.  An episode
+// that isn't available in the market results in a *NotFoundError.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-an-episode
+// Supported options: Market
+func (c *Client) GetEpisode(ctx context.Context, id ID, opts ...RequestOption) (*FullEpisode, error) {
+	spotifyURL := c.baseURL + "episodes/" + string(id)
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result FullEpisode
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetEpisodes retrieves information about several episodes.  The API
+// supports up to 50 episodes in a single call; larger sets of IDs are split
+// into several calls transparently.  Episodes are returned in the order
+// requested.  If an episode is not found or isn't available in the market,
+// that position in the result will be nil.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-multiple-episodes
+// Supported options: Market
+func (c *Client) GetEpisodes(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullEpisode, error) {
+	params := processOptions(opts...).urlParams
+
+	episodes := make([]*FullEpisode, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, 50) {
+		params.Set("ids", strings.Join(toStringSlice(chunk), ","))
+		spotifyURL := fmt.Sprintf("%sepisodes?%s", c.baseURL, params.Encode())
+
+		var e struct {
+			Episodes []*FullEpisode `json:"episodes"`
+		}
+
+		err := c.get(ctx, spotifyURL, &e)
+		if err != nil {
+			return nil, err
+		}
+
+		episodes = append(episodes, e.Episodes...)
+	}
+
+	return episodes, nil
+}
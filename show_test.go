@@ -2,7 +2,10 @@ package spotify
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -40,3 +43,129 @@ func TestGetShowEpisodes(t *testing.T) {
 		t.Error("Invalid data", len(r.Episodes))
 	}
 }
+
+func TestGetShowEpisodesNulls(t *testing.T) {
+	c, s := testClientString(http.StatusOK, `{"items": [{"id": "2DSKnz9Hqm1tKimcXqcMJD", "name": "Paradigm's Thesis for Crypto"}, null], "total": 2}`)
+	defer s.Close()
+
+	r, err := c.GetShowEpisodes(context.Background(), "3vuV292Him90EjQ5YL4XIw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Episodes) != 2 {
+		t.Fatal("Invalid data", len(r.Episodes))
+	}
+	if r.Episodes[1].ID != "" {
+		t.Error("Expected a zero-valued episode for null, got", r.Episodes[1].ID)
+	}
+}
+
+func TestGetEpisode(t *testing.T) {
+	c, s := testClientFile(http.StatusOK, "test_data/get_episode.txt", func(r *http.Request) {
+		if r.URL.Path != "/episodes/2DSKnz9Hqm1tKimcXqcMJD" {
+			t.Error("Invalid path:", r.URL.Path)
+		}
+		if m := r.URL.Query().Get("market"); m != CountryUSA {
+			t.Error("Invalid market:", m)
+		}
+	})
+	defer s.Close()
+
+	e, err := c.GetEpisode(context.Background(), "2DSKnz9Hqm1tKimcXqcMJD", Market(CountryUSA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "Paradigm's Thesis for Crypto" || !e.IsPlayable {
+		t.Error("Invalid data:", e.Name, e.IsPlayable)
+	}
+	if e.Show.Name != "Uncommon Core" {
+		t.Error("Invalid show:", e.Show.Name)
+	}
+	if e.ResumePoint.ResumePositionMs != 120000 {
+		t.Error("Invalid resume point:", e.ResumePoint.ResumePositionMs)
+	}
+	if d := e.ReleaseDateTime(); d.Year() != 2021 || d.Month() != 5 || d.Day() != 26 {
+		t.Error("Invalid release date:", d)
+	}
+}
+
+func TestGetEpisodesUnavailableInMarket(t *testing.T) {
+	c, s := testClientFile(http.StatusOK, "test_data/get_episodes.txt", func(r *http.Request) {
+		if ids := r.URL.Query().Get("ids"); ids != "2DSKnz9Hqm1tKimcXqcMJD,unavailable" {
+			t.Error("Invalid ids:", ids)
+		}
+		if m := r.URL.Query().Get("market"); m != CountryBrazil {
+			t.Error("Invalid market:", m)
+		}
+	})
+	defer s.Close()
+
+	episodes, err := c.GetEpisodes(context.Background(), []ID{"2DSKnz9Hqm1tKimcXqcMJD", "unavailable"}, Market(CountryBrazil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(episodes) != 2 {
+		t.Fatal("Invalid data", len(episodes))
+	}
+	if episodes[0] == nil || episodes[0].ID != "2DSKnz9Hqm1tKimcXqcMJD" {
+		t.Error("Invalid episode:", episodes[0])
+	}
+	if episodes[1] != nil {
+		t.Error("Expected nil for an episode unavailable in the market, got", episodes[1])
+	}
+}
+
+func TestGetShows(t *testing.T) {
+	c, s := testClientFile(http.StatusOK, "test_data/get_shows.txt")
+	defer s.Close()
+
+	shows, err := c.GetShows(context.Background(), []ID{"3vuV292Him90EjQ5YL4XIw", "unavailable"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shows) != 2 {
+		t.Fatal("Invalid data", len(shows))
+	}
+	if shows[0] == nil || shows[0].Publisher != "Su Zhu and Hasu" {
+		t.Error("Invalid show:", shows[0])
+	}
+	if shows[1] != nil {
+		t.Error("Expected nil for an unavailable show, got", shows[1])
+	}
+}
+
+func TestGetEpisodesAndShowsBatched(t *testing.T) {
+	c, server, batches := batchServer(t, func(w http.ResponseWriter, r *http.Request, ids []string) {
+		if m := r.URL.Query().Get("market"); m != CountryUSA {
+			t.Error("Invalid market:", m)
+		}
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id": %q}`, id)
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		fmt.Fprintf(w, `{%q: [%s]}`, key, strings.Join(items, ","))
+	})
+	defer server.Close()
+
+	ids := make([]ID, 75)
+	for i := range ids {
+		ids[i] = ID(fmt.Sprintf("id%d", i))
+	}
+	episodes, err := c.GetEpisodes(context.Background(), ids, Market(CountryUSA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shows, err := c.GetShows(context.Background(), ids, Market(CountryUSA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*batches, []int{50, 25, 50, 25}) {
+		t.Errorf("Expected batches of [50 25 50 25], got %v", *batches)
+	}
+	for i, id := range ids {
+		if episodes[i].ID != id || shows[i].ID != id {
+			t.Fatalf("Result %d out of order: %s, %s", i, episodes[i].ID, shows[i].ID)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClientShutdown is the error returned for requests issued after
+// Shutdown has been called on the client.
+var ErrClientShutdown = errors.New("spotify: client is shut down")
+
+// Shutdown stops the client from accepting new requests and waits for the
+// in-flight ones to finish.  Requests issued once Shutdown has been called
+// fail with ErrClientShutdown.  If ctx expires before the in-flight requests
+// have drained, Shutdown returns the context's error and leaves the remaining
+// requests running.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// track registers a new in-flight request.  The returned function must be
+// called once the request has completed.
+func (c *Client) track() (done func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientShutdown
+	}
+	c.inflight.Add(1)
+	return c.inflight.Done, nil
+}
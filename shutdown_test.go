@@ -0,0 +1,87 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// blockingServer returns a server that signals on started for every request
+// it receives and does not respond until release is closed.
+func blockingServer(started chan<- struct{}, release <-chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"genres": ["rock"]}`))
+	}))
+}
+
+func TestShutdownDrainsInFlight(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	server := blockingServer(started, release)
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	reqErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetAvailableGenreSeeds(context.Background())
+		reqErr <- err
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- client.Shutdown(context.Background())
+	}()
+
+	// wait for Shutdown to stop accepting requests
+	for {
+		client.mu.Lock()
+		closed := client.closed
+		client.mu.Unlock()
+		if closed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := client.GetAvailableGenreSeeds(context.Background()); !errors.Is(err, ErrClientShutdown) {
+		t.Errorf("Expected ErrClientShutdown, got %v", err)
+	}
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Shutdown returned before the in-flight request finished: %v", err)
+	default:
+	}
+
+	close(release)
+	if err := <-reqErr; err != nil {
+		t.Errorf("In-flight request failed: %v", err)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
+
+func TestShutdownContextExpires(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	server := blockingServer(started, release)
+	defer server.Close()
+	defer close(release)
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	go func() {
+		_, _ = client.GetAvailableGenreSeeds(context.Background())
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"io"
 	"io/ioutil"
 	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/metric"
@@ -46,11 +48,32 @@ const (
 // Client is a client for working with the Spotify Web API.
 // It is best to create this using spotify.New()
 type Client struct {
-	http    *http.Client
-	baseURL string
-
-	autoRetry      bool
-	acceptLanguage string
+	http     *http.Client
+	baseURL  string
+	tokenURL string
+	// oauth2 authenticates the requests when the client's transport was
+	// wrapped by WithRoundTripper.
+	oauth2 *oauth2.Transport
+
+	autoRetry       bool
+	acceptLanguage  string
+	requestIDHeader string
+
+	logger               *slog.Logger
+	slowRequestThreshold time.Duration
+	maxResponseBytes     int64
+	responseInspector    func(*http.Response)
+	pagePrefetch         int
+
+	genreSeedTTL time.Duration
+	genreSeeds   genreSeedCache
+
+	clock clock
+
+	// mu guards closed; inflight counts the requests Shutdown waits on.
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
 }
 
 type ClientOption func(client *Client)
@@ -77,13 +100,53 @@ func WithAcceptLanguage(lang string) ClientOption {
 	}
 }
 
+// WithLogger configures the logger used to report requests, retries and slow
+// responses.  By default the client logs to slog.Default().
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// WithSlowRequestThreshold configures the client to log a warning for every
+// request that takes longer than d to complete.  A threshold of 0, the
+// default, disables the warning.
+func WithSlowRequestThreshold(d time.Duration) ClientOption {
+	return func(client *Client) {
+		client.slowRequestThreshold = d
+	}
+}
+
+// WithMaxResponseBytes limits the size of the response bodies the client
+// reads to n bytes.  Larger responses fail with a ResponseTooLargeError
+// instead of being buffered in memory.  Clients created with New default
+// to a 10 MiB limit; a limit of 0 disables it.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(client *Client) {
+		client.maxResponseBytes = n
+	}
+}
+
+// WithResponseInspector configures the client to call inspect with every
+// response it receives, successful or not and including the ones that are
+// retried, before the body is decoded.  It can be used to record headers
+// such as X-RateLimit-Remaining returned by proxies.  inspect must not read
+// or close the response body.
+func WithResponseInspector(inspect func(*http.Response)) ClientOption {
+	return func(client *Client) {
+		client.responseInspector = inspect
+	}
+}
+
 // New returns a client for working with the Spotify Web API.
 // The provided httpClient must provide Authentication with the requests.
 // The auth package may be used to generate a suitable client.
 func New(httpClient *http.Client, opts ...ClientOption) *Client {
 	c := &Client{
-		http:    httpClient,
-		baseURL: "https://api.spotify.com/v1/",
+		http:             httpClient,
+		baseURL:          "https://api.spotify.com/v1/",
+		maxResponseBytes: defaultMaxResponseBytes,
+		pagePrefetch:     defaultPagePrefetch,
 	}
 
 	for _, opt := range opts {
@@ -93,6 +156,41 @@ func New(httpClient *http.Client, opts ...ClientOption) *Client {
 	return c
 }
 
+// Clone returns a copy of the client with opts applied on top of its
+// configuration, leaving c untouched.  The copy has its own http.Client but
+// shares c's transport, so both clients use the same oauth2 token source and
+// token refreshes stay coordinated.  Shutting down one of the clients
+// doesn't affect the other.
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	httpClient := *c.http
+	clone := &Client{
+		http:     &httpClient,
+		baseURL:  c.baseURL,
+		tokenURL: c.tokenURL,
+		oauth2:   c.oauth2,
+
+		autoRetry:       c.autoRetry,
+		acceptLanguage:  c.acceptLanguage,
+		requestIDHeader: c.requestIDHeader,
+
+		logger:               c.logger,
+		slowRequestThreshold: c.slowRequestThreshold,
+		maxResponseBytes:     c.maxResponseBytes,
+		responseInspector:    c.responseInspector,
+		pagePrefetch:         c.pagePrefetch,
+
+		genreSeedTTL: c.genreSeedTTL,
+
+		clock: c.clock,
+	}
+
+	for _, opt := range opts {
+		opt(clone)
+	}
+
+	return clone
+}
+
 // URI identifies an artist, album, track, or category.  For example,
 // spotify:track:6rqhFgbbKwnb9MLmUQDhG6
 type URI string
@@ -140,14 +238,62 @@ func (i Image) Download(dst io.Writer) error {
 	return err
 }
 
+// LargestImage returns the image with the greatest area, or nil if images is
+// empty.  Images with unknown dimensions are only returned if no image has
+// known dimensions.
+func LargestImage(images []Image) *Image {
+	var largest *Image
+	for i := range images {
+		img := &images[i]
+		if largest == nil || img.Width*img.Height > largest.Width*largest.Height {
+			largest = img
+		}
+	}
+	return largest
+}
+
+// ImageClosestTo returns the image whose width is closest to width, or nil if
+// images is empty.  When two images are equally close, the wider one is
+// returned so that callers scale down rather than up.  As with LargestImage,
+// images with an unknown width are only returned if no image has a known
+// width.
+func ImageClosestTo(images []Image, width int) *Image {
+	var closest *Image
+	var closestDist float64
+	for i := range images {
+		img := &images[i]
+		if closest != nil && img.Width == 0 {
+			continue
+		}
+		dist := math.Abs(img.Width - float64(width))
+		if closest == nil || closest.Width == 0 || dist < closestDist || (dist == closestDist && img.Width > closest.Width) {
+			closest, closestDist = img, dist
+		}
+	}
+	return closest
+}
+
 // Error represents an error returned by the Spotify Web API.
 type Error struct {
 	// A short description of the error.
 	Message string `json:"message"`
 	// The HTTP status code.
 	Status int `json:"status"`
+	// A machine readable reason for the failure, only set by some
+	// endpoints such as the player ones (for example ReasonNoActiveDevice).
+	Reason string `json:"reason"`
 }
 
+// Reasons reported by the player endpoints in Error.Reason.
+const (
+	// ReasonNoActiveDevice means the command was sent while the user
+	// had no active device to run it on.
+	ReasonNoActiveDevice = "NO_ACTIVE_DEVICE"
+	// ReasonPremiumRequired means the command requires a Spotify Premium
+	// account.
+	ReasonPremiumRequired = "PREMIUM_REQUIRED"
+)
+
 func (e Error) Error() string {
 	return e.Message
 }
@@ -159,8 +305,14 @@ func (c *Client) decodeError(resp *http.Response) error {
 		return err
 	}
 
+	notFound := resp.StatusCode == http.StatusNotFound
+
 	if len(responseBody) == 0 {
-		return fmt.Errorf("spotify: HTTP %d: %s (body empty)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		msg := fmt.Sprintf("spotify: HTTP %d: %s (body empty)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		if notFound {
+			return &NotFoundError{Error{Message: msg, Status: resp.StatusCode}}
+		}
+		return errors.New(msg)
 	}
 
 	buf := bytes.NewBuffer(responseBody)
@@ -170,7 +322,13 @@ func (c *Client) decodeError(resp *http.Response) error {
 	}
 	err = json.NewDecoder(buf).Decode(&e)
 	if err != nil {
-		return fmt.Errorf("spotify: couldn't decode error: (%d) [%s]", len(responseBody), responseBody)
+		// The body isn't one of Spotify's error objects, for example an
+		// HTML page returned by a proxy.
+		msg := fmt.Sprintf("spotify: couldn't decode error: (%d) [%s]", len(responseBody), responseBody)
+		if notFound {
+			return &NotFoundError{Error{Message: msg, Status: resp.StatusCode}}
+		}
+		return errors.New(msg)
 	}
 
 	if e.E.Message == "" {
@@ -184,13 +342,28 @@ func (c *Client) decodeError(resp *http.Response) error {
 			resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
+	if notFound {
+		if e.E.Status == 0 {
+			e.E.Status = resp.StatusCode
+		}
+		return &NotFoundError{e.E}
+	}
 	return e.E
 }
 
 // shouldRetry determines whether the status code indicates that the
-// previous operation should be retried at a later time
-func shouldRetry(status int) bool {
-	return status == http.StatusAccepted || status == http.StatusTooManyRequests
+// previous operation should be retried at a later time. A 202 Accepted
+// usually means Spotify is still processing the request asynchronously,
+// but endpoints that list it in needsStatus use it as a terminal success
+// and must not be retried.
+func shouldRetry(status int, needsStatus []int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusAccepted:
+		return isFailure(status, needsStatus)
+	}
+	return false
 }
 
 // isFailure determines whether the code indicates failure
@@ -207,59 +380,98 @@ func isFailure(code int, validCodes []int) bool {
 // status codes that will be treated as success. Note that we allow all 200s
 // even if there are additional success codes that represent success.
 func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...int) error {
-	logger := slog.With(":spotify", true, "url", req.URL.String())
+	return c.do(req, result, needsStatus...)
+}
+
+// do sends req and decodes the response into result. It is the single path
+// shared by get and execute: any 2xx or a status listed in needsStatus is a
+// success, a 429 is always retried (when auto retry is enabled), and a 202 is
+// retried unless the caller listed it in needsStatus.
+func (c *Client) do(req *http.Request, result interface{}, needsStatus ...int) error {
+	done, err := c.track()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ctx := req.Context()
+	clk := c.clk()
+	log := c.log()
+	logger := log.With(":spotify", true, "url", req.URL.String())
 
 	if c.acceptLanguage != "" {
 		req.Header.Set("Accept-Language", c.acceptLanguage)
 	}
+	requestID, err := c.setRequestID(req)
+	if err != nil {
+		return err
+	}
+	if c.oauth2 != nil {
+		req = req.WithContext(context.WithValue(ctx, oauth2TransportKey{}, c.oauth2))
+	}
 	for {
-		beforeReq := time.Now().UTC()
-		logger.DebugContext(req.Context(), "request spotify")
+		beforeReq := clk.Now()
+		logger.DebugContext(ctx, "request spotify", ":spotify-req", true)
 		resp, err := c.http.Do(req)
 
 		var statusCode int
 		if resp != nil {
 			statusCode = resp.StatusCode
 		}
-		ellapsed := time.Since(beforeReq)
+		ellapsed := clk.Now().Sub(beforeReq)
 
 		// observability: metrics
 		// observability: logs
-		metricLatencyHist.Record(req.Context(), int64(ellapsed/time.Millisecond),
+		metricLatencyHist.Record(ctx, int64(ellapsed/time.Millisecond),
 			metric.WithAttributes(
 				semconv.HTTPStatusCode(statusCode),
 				semconv.HTTPRoute(req.URL.Path),
 			),
 		)
+		if c.slowRequestThreshold > 0 && ellapsed > c.slowRequestThreshold {
+			logger.WarnContext(ctx, "slow spotify request",
+				"route", req.URL.Path, "ellapsed", ellapsed, "status", statusCode)
+		}
 
+		attrs := []any{":spotify-resp", true, "err", err, "ellapsed", ellapsed,
+			"status", statusCode}
+		if requestID != "" {
+			attrs = append(attrs, "requestID", requestID)
+		}
+		if spotifyRequestID := responseRequestID(resp, requestID); spotifyRequestID != "" {
+			attrs = append(attrs, "spotifyRequestID", spotifyRequestID)
+		}
 		switch statusCode {
 		case rateLimitExceededStatusCode:
 			retryAfter := resp.Header.Get("retry-after")
-			slog.WarnContext(req.Context(), "will retry...",
-				":spotify-resp", true, "err", err, "ellapsed", ellapsed,
-				"status", statusCode, "retryAfter", retryAfter)
+			log.WarnContext(ctx, "will retry...", append(attrs, "retryAfter", retryAfter)...)
 		default:
-			slog.DebugContext(req.Context(), "spotify response",
-				":spotify-resp", true, "err", err, "ellapsed", ellapsed,
-				"status", statusCode)
+			log.DebugContext(ctx, "spotify response", attrs...)
 		}
 
 		if err != nil {
 			return err
 		}
-		defer resp.Body.Close()
+		if c.maxResponseBytes > 0 {
+			resp.Body = newLimitedBody(resp.Body, c.maxResponseBytes)
+		}
+		if c.responseInspector != nil {
+			c.responseInspector(resp)
+		}
 
-		if shouldRetry(resp.StatusCode) {
+		if shouldRetry(resp.StatusCode, needsStatus) {
+			resp.Body.Close()
 			if c.autoRetry {
-				logger.WarnContext(req.Context(), "rate limit exceeded", "retry", retryDuration(resp))
-				if err := sleep(req.Context(), retryDuration(resp)); err != nil {
+				logger.WarnContext(ctx, "rate limit exceeded", "retry", retryDuration(resp))
+				if err := clk.Sleep(ctx, retryDuration(resp)); err != nil {
 					return err
 				}
 				continue
-			} else {
-				return &TooManyRequestsError{retryDuration(resp)}
 			}
+			return &TooManyRequestsError{retryDuration(resp)}
 		}
+		defer resp.Body.Close()
+
 		if resp.StatusCode == http.StatusNoContent {
 			return nil
 		}
@@ -270,13 +482,18 @@ func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...i
 		}
 
 		if result != nil {
-			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			// buffer the body, bounded by maxResponseBytes, so that
+			// decode failures can report what it looked like
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
 				return err
 			}
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(result); err != nil {
+				return newDecodeError(req.URL.String(), resp.StatusCode, body, err)
+			}
 		}
-		break
+		return nil
 	}
-	return nil
 }
 
 func retryDuration(resp *http.Response) time.Duration {
@@ -292,73 +509,20 @@ func retryDuration(resp *http.Response) time.Duration {
 }
 
 func (c *Client) get(ctx context.Context, url string, result interface{}) error {
-	logger := slog.With(":spotify", true, "url", url)
-
-	for {
-		beforeReq := time.Now().UTC()
-		logger.DebugContext(ctx, "request spotify", ":spotify-req", true)
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if c.acceptLanguage != "" {
-			req.Header.Set("Accept-Language", c.acceptLanguage)
-		}
-		if err != nil {
-			logger.ErrorContext(ctx, "unable to request spotify", "err", err)
-			return err
-		}
-		resp, err := c.http.Do(req)
-		ellapsed := time.Since(beforeReq)
-
-		var statusCode int
-		if resp != nil {
-			statusCode = resp.StatusCode
-		}
-		metricLatencyHist.Record(req.Context(), int64(ellapsed/time.Millisecond),
-			metric.WithAttributes(
-				semconv.HTTPStatusCode(statusCode),
-				semconv.HTTPRoute(req.URL.Path),
-			),
-		)
-
-		switch statusCode {
-		case rateLimitExceededStatusCode:
-			retryAfter := resp.Header.Get("retry-after")
-			slog.WarnContext(req.Context(), "will retry...",
-				":spotify-resp", true, "err", err, "ellapsed", ellapsed,
-				"status", statusCode, "retryAfter", retryAfter)
-		}
-
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == rateLimitExceededStatusCode {
-			if c.autoRetry {
-				logger.WarnContext(ctx, "rate limit exceeded", "retry", retryDuration(resp))
-				if err := sleep(ctx, retryDuration(resp)); err != nil {
-					return err
-				}
-				continue
-			} else {
-				return &TooManyRequestsError{retryDuration(resp)}
-			}
-		}
-		if resp.StatusCode == http.StatusNoContent {
-			return nil
-		}
-		if resp.StatusCode != http.StatusOK {
-			return c.decodeError(resp)
-		}
-
-		err = json.NewDecoder(resp.Body).Decode(result)
-		if err != nil {
-			return err
-		}
-
-		break
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		c.log().ErrorContext(ctx, "unable to request spotify", ":spotify", true, "url", url, "err", err)
+		return err
 	}
+	return c.do(req, result)
+}
 
-	return nil
+// log returns the client's logger, falling back to the default one.
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
 }
 
 func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
@@ -390,7 +554,7 @@ func (c *Client) NewReleases(ctx context.Context, opts ...RequestOption) (albums
 
 // Token gets the client's current token.
 func (c *Client) Token() (*oauth2.Token, error) {
-	transport, ok := c.http.Transport.(*oauth2.Transport)
+	transport, ok := c.oauth2Transport()
 	if !ok {
 		return nil, errors.New("spotify: client not backed by oauth2 transport")
 	}
@@ -2,12 +2,17 @@ package spotify
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"golang.org/x/oauth2"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -48,6 +53,49 @@ func testClientFile(code int, filename string, validators ...func(*http.Request)
 	return testClient(code, f, validators...)
 }
 
+// Returns a client whose requests are answered by respond, along with
+// the number of IDs sent in each request.  The IDs are read from the ids
+// query parameter, or from the JSON body of requests without one.
+func batchServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request, ids []string)) (*Client, *httptest.Server, *[]int) {
+	batches := &[]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		if query := r.URL.Query().Get("ids"); query != "" {
+			ids = strings.Split(query, ",")
+		} else {
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			ids = body.IDs
+		}
+		*batches = append(*batches, len(ids))
+		respond(w, r, ids)
+	}))
+	client := &Client{
+		http:    http.DefaultClient,
+		baseURL: server.URL + "/",
+	}
+	return client, server, batches
+}
+
+// Returns a batchServer answering every request with an object holding,
+// under key, one item per requested ID with just that ID.
+func batchEchoServer(t *testing.T, key string, validators ...func(*http.Request)) (*Client, *httptest.Server, *[]int) {
+	return batchServer(t, func(w http.ResponseWriter, r *http.Request, ids []string) {
+		for _, v := range validators {
+			v(r)
+		}
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id": %q}`, id)
+		}
+		fmt.Fprintf(w, `{%q: [%s]}`, key, strings.Join(items, ","))
+	})
+}
+
 func TestNewReleases(t *testing.T) {
 	c, s := testClientFile(http.StatusOK, "test_data/new_releases.txt")
 	defer s.Close()
@@ -161,3 +209,343 @@ func TestClient_Token(t *testing.T) {
 		}
 	})
 }
+
+func TestExecuteAcceptedAsSuccess(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/", autoRetry: true}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, client.baseURL+"accepted", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.execute(req, nil, http.StatusAccepted); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single request, got %d", calls)
+	}
+}
+
+func TestExecuteAcceptedRetried(t *testing.T) {
+	client, server := testClientString(http.StatusAccepted, "")
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, client.baseURL+"accepted", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.execute(req, nil)
+	if _, ok := err.(*TooManyRequestsError); !ok {
+		t.Errorf("Expected *TooManyRequestsError, got %v", err)
+	}
+}
+
+func TestImageHelpers(t *testing.T) {
+	images := []Image{
+		{Width: 640, Height: 640, URL: "640"},
+		{Width: 300, Height: 300, URL: "300"},
+		{Width: 64, Height: 64, URL: "64"},
+	}
+
+	tests := []struct {
+		name    string
+		images  []Image
+		width   int
+		largest string
+		closest string
+	}{
+		{"empty", nil, 100, "", ""},
+		{"single", images[2:], 1000, "64", "64"},
+		{"exact match", images, 300, "640", "300"},
+		{"between sizes", images, 200, "640", "300"},
+		{"tie prefers wider", images, 182, "640", "300"},
+		{"smaller than all", images, 10, "640", "64"},
+		{"larger than all", images, 2000, "640", "640"},
+		{"unsorted", []Image{images[2], images[0], images[1]}, 500, "640", "640"},
+		{"unknown dimensions", []Image{{URL: "unknown"}}, 300, "unknown", "unknown"},
+		{"unknown mixed with known", []Image{{URL: "unknown"}, images[0]}, 100, "640", "640"},
+		{"known mixed with unknown", []Image{images[2], {URL: "unknown"}}, 0, "64", "64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LargestImage(tt.images); imageURL(got) != tt.largest {
+				t.Errorf("LargestImage: want %q, got %q", tt.largest, imageURL(got))
+			}
+			if got := ImageClosestTo(tt.images, tt.width); imageURL(got) != tt.closest {
+				t.Errorf("ImageClosestTo(%d): want %q, got %q", tt.width, tt.closest, imageURL(got))
+			}
+		})
+	}
+}
+
+func imageURL(img *Image) string {
+	if img == nil {
+		return ""
+	}
+	return img.URL
+}
+
+// recordingHandler is a slog.Handler that keeps every record it handles.
+type recordingHandler struct {
+	*recordedLogs
+	attrs []slog.Attr
+}
+
+type recordedLogs struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{recordedLogs: &recordedLogs{}}
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	r = r.Clone()
+	r.AddAttrs(h.attrs...)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordingHandler{recordedLogs: h.recordedLogs, attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+
+func (h *recordingHandler) WithGroup(string) slog.Handler { return h }
+
+// find returns the attributes of the first record with the given message.
+func (h *recordingHandler) find(msg string) (map[string]slog.Value, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.records {
+		if r.Message != msg {
+			continue
+		}
+		attrs := map[string]slog.Value{}
+		r.Attrs(func(a slog.Attr) bool {
+			attrs[a.Key] = a.Value
+			return true
+		})
+		return attrs, true
+	}
+	return nil, false
+}
+
+func TestSlowRequestThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		warned    bool
+	}{
+		{"disabled", 0, false},
+		{"under threshold", 2 * time.Second, false},
+		{"over threshold", 500 * time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, s := testClientFile(http.StatusOK, "test_data/new_releases.txt")
+			defer s.Close()
+			h := newRecordingHandler()
+			c.logger = slog.New(h)
+			c.clock = &fakeClock{step: time.Second}
+			c.slowRequestThreshold = tt.threshold
+
+			if _, err := c.NewReleases(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			attrs, warned := h.find("slow spotify request")
+			if warned != tt.warned {
+				t.Fatalf("Expected warning: %v, got %v", tt.warned, warned)
+			}
+			if !warned {
+				return
+			}
+			if route := attrs["route"].String(); route != "/browse/new-releases" {
+				t.Errorf("Expected route /browse/new-releases, got %s", route)
+			}
+			if ellapsed := attrs["ellapsed"].Duration(); ellapsed != time.Second {
+				t.Errorf("Expected 1s elapsed, got %s", ellapsed)
+			}
+		})
+	}
+}
+
+func TestDecodeErrorNotFound(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		nf      bool
+		message string
+	}{
+		{"not found", http.StatusNotFound, `{"error": {"status": 404, "message": "Non existing id: 'spotify:track:asdf'"}}`, true, "Non existing id: 'spotify:track:asdf'"},
+		{"not found without body", http.StatusNotFound, "", true, "spotify: HTTP 404: Not Found (body empty)"},
+		{"not found from a proxy", http.StatusNotFound, "<html>Not Found</html>", true, "spotify: couldn't decode error: (22) [<html>Not Found</html>]"},
+		{"not found with a string error", http.StatusNotFound, `{"error": "no such track"}`, true, `spotify: couldn't decode error: (26) [{"error": "no such track"}]`},
+		{"bad gateway from a proxy", http.StatusBadGateway, "<html>Bad Gateway</html>", false, "spotify: couldn't decode error: (24) [<html>Bad Gateway</html>]"},
+		{"bad request", http.StatusBadRequest, `{"error": {"status": 400, "message": "invalid id"}}`, false, "invalid id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := testClientString(tt.status, tt.body)
+			defer server.Close()
+
+			_, err := client.GetTrack(context.Background(), "asdf")
+			var nf *NotFoundError
+			if errors.As(err, &nf) != tt.nf {
+				t.Fatalf("Expected *NotFoundError: %v, got %T", tt.nf, err)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, err.Error())
+			}
+			if tt.nf && nf.Err.Status != http.StatusNotFound {
+				t.Errorf("Expected status 404, got %d", nf.Err.Status)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	oversized := `{"genres": ["` + strings.Repeat("a", 2048) + `"]}`
+	oversizedError := `{"error": {"status": 500, "message": "` + strings.Repeat("a", 2048) + `"}}`
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		limit  int64
+		large  bool
+	}{
+		{"oversized", http.StatusOK, oversized, 1024, true},
+		{"oversized error", http.StatusInternalServerError, oversizedError, 1024, true},
+		{"exactly at limit", http.StatusOK, oversized, int64(len(oversized)), false},
+		{"disabled", http.StatusOK, oversized, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := testClientString(tt.status, tt.body)
+			defer server.Close()
+			WithMaxResponseBytes(tt.limit)(client)
+
+			_, err := client.GetAvailableGenreSeeds(context.Background())
+			var tooLarge *ResponseTooLargeError
+			if errors.As(err, &tooLarge) != tt.large {
+				t.Fatalf("Expected *ResponseTooLargeError: %v, got %v", tt.large, err)
+			}
+			if tt.large && tooLarge.Limit != tt.limit {
+				t.Errorf("Expected limit %d, got %d", tt.limit, tooLarge.Limit)
+			}
+			if !tt.large && tt.status == http.StatusOK && err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewDefaultMaxResponseBytes(t *testing.T) {
+	if c := New(http.DefaultClient); c.maxResponseBytes != defaultMaxResponseBytes {
+		t.Errorf("Expected default limit of %d, got %d", defaultMaxResponseBytes, c.maxResponseBytes)
+	}
+}
+
+func TestClientClone(t *testing.T) {
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+		_, _ = io.WriteString(w, `{"genres": []}`)
+	}))
+	defer server.Close()
+
+	transport := &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		Base:   http.DefaultTransport,
+	}
+	original := New(&http.Client{Transport: transport}, WithBaseURL(server.URL+"/"), WithAcceptLanguage("en"), WithRetry(true))
+	clone := original.Clone(WithAcceptLanguage("es"), WithMaxResponseBytes(0))
+
+	if original.acceptLanguage != "en" || original.maxResponseBytes != defaultMaxResponseBytes {
+		t.Error("The original client was modified")
+	}
+	if clone.acceptLanguage != "es" || clone.maxResponseBytes != 0 {
+		t.Error("The options were not applied to the clone")
+	}
+	if clone.baseURL != original.baseURL || !clone.autoRetry {
+		t.Error("The clone didn't keep the original configuration")
+	}
+	if clone.http == original.http {
+		t.Error("The clone should have its own http.Client")
+	}
+	if clone.http.Transport != transport {
+		t.Error("The clone should share the oauth2 transport")
+	}
+	if _, err := clone.Token(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := original.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clone.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 2 || languages[0] != "en" || languages[1] != "es" {
+		t.Errorf("Expected Accept-Language [en es], got %v", languages)
+	}
+
+	if err := clone.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := original.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Errorf("Shutting down the clone should not affect the original: %v", err)
+	}
+}
+
+func TestResponseInspector(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"success", http.StatusOK, `{"genres": ["rock"]}`},
+		{"rate limited", rateLimitExceededStatusCode, `{"error": {"status": 429, "message": "slow down"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "42")
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			var statuses []int
+			var remaining []string
+			client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+			WithResponseInspector(func(resp *http.Response) {
+				statuses = append(statuses, resp.StatusCode)
+				remaining = append(remaining, resp.Header.Get("X-RateLimit-Remaining"))
+			})(client)
+
+			genres, err := client.GetAvailableGenreSeeds(context.Background())
+			if tt.status == http.StatusOK && (err != nil || len(genres) != 1) {
+				t.Errorf("The body should still be decoded, got %v, %v", genres, err)
+			}
+			if len(statuses) != 1 || statuses[0] != tt.status {
+				t.Errorf("Expected the inspector to see status %d, got %v", tt.status, statuses)
+			}
+			if len(remaining) != 1 || remaining[0] != "42" {
+				t.Errorf("Expected the inspector to see the rate limit header, got %v", remaining)
+			}
+		})
+	}
+}
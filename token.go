@@ -0,0 +1,65 @@
+package spotify
+
+import (
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// WithTokenNotify configures the client to call notify whenever the oauth2
+// transport starts using a new access token, for example after it has been
+// silently refreshed.  This lets services that share tokens between workers
+// persist the new token.  The option has no effect if the client's HTTP
+// client is not backed by an oauth2 transport; the HTTP client passed to New
+// is left untouched.
+func WithTokenNotify(notify func(*oauth2.Token)) ClientOption {
+	return func(client *Client) {
+		transport, ok := client.oauth2Transport()
+		if !ok {
+			return
+		}
+		notifying := *transport
+		notifying.Source = WrapTokenSource(transport.Source, notify)
+
+		if client.oauth2 != nil {
+			client.oauth2 = &notifying
+			return
+		}
+		httpClient := *client.http
+		httpClient.Transport = &notifying
+		client.http = &httpClient
+	}
+}
+
+// WrapTokenSource returns a TokenSource that returns the tokens of src and
+// calls notify every time the access token differs from the last one it
+// returned, including the first token it sees.  It is useful to users who
+// build their own oauth2 client and want to be told about refreshed tokens.
+func WrapTokenSource(src oauth2.TokenSource, notify func(*oauth2.Token)) oauth2.TokenSource {
+	return &notifyingTokenSource{src: src, notify: notify}
+}
+
+type notifyingTokenSource struct {
+	src    oauth2.TokenSource
+	notify func(*oauth2.Token)
+
+	mu   sync.Mutex
+	last string
+}
+
+func (s *notifyingTokenSource) Token() (*oauth2.Token, error) {
+	t, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	changed := t.AccessToken != s.last
+	s.last = t.AccessToken
+	s.mu.Unlock()
+
+	if changed {
+		s.notify(t)
+	}
+	return t, nil
+}
@@ -0,0 +1,95 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// rotatingTokenSource hands out the tokens it holds in order, repeating
+// the last one once exhausted.
+type rotatingTokenSource struct {
+	tokens []string
+	calls  int
+}
+
+func (s *rotatingTokenSource) Token() (*oauth2.Token, error) {
+	i := s.calls
+	if i >= len(s.tokens) {
+		i = len(s.tokens) - 1
+	}
+	s.calls++
+	return &oauth2.Token{AccessToken: s.tokens[i], TokenType: "Bearer"}, nil
+}
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("no token")
+}
+
+func TestWrapTokenSource(t *testing.T) {
+	src := &rotatingTokenSource{tokens: []string{"a", "a", "b", "b", "c"}}
+	var notified []string
+	wrapped := WrapTokenSource(src, func(t *oauth2.Token) {
+		notified = append(notified, t.AccessToken)
+	})
+
+	for i := 0; i < 6; i++ {
+		if _, err := wrapped.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(notified) != 3 || notified[0] != "a" || notified[1] != "b" || notified[2] != "c" {
+		t.Errorf("Expected notifications for [a b c], got %v", notified)
+	}
+}
+
+func TestWrapTokenSourceError(t *testing.T) {
+	wrapped := WrapTokenSource(failingTokenSource{}, func(*oauth2.Token) {
+		t.Error("notify should not be called on error")
+	})
+	if _, err := wrapped.Token(); err == nil {
+		t.Error("Expected the source's error")
+	}
+}
+
+func TestWithTokenNotify(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"genres": []}`))
+	}))
+	defer server.Close()
+
+	transport := &oauth2.Transport{Source: &rotatingTokenSource{tokens: []string{"first", "first", "second"}}}
+	httpClient := &http.Client{Transport: transport}
+
+	var notified []string
+	client := New(httpClient, WithBaseURL(server.URL+"/"), WithTokenNotify(func(t *oauth2.Token) {
+		notified = append(notified, t.AccessToken)
+	}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetAvailableGenreSeeds(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(notified) != 2 || notified[0] != "first" || notified[1] != "second" {
+		t.Errorf("Expected notifications for [first second], got %v", notified)
+	}
+	if auth[2] != "Bearer second" {
+		t.Errorf("Expected the rotated token to be sent, got %s", auth[2])
+	}
+	if _, ok := transport.Source.(*rotatingTokenSource); !ok || httpClient.Transport != transport {
+		t.Error("The caller's HTTP client should not be modified")
+	}
+	if _, err := client.Token(); err != nil {
+		t.Errorf("Token should still find the oauth2 transport: %v", err)
+	}
+}
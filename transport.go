@@ -0,0 +1,66 @@
+package spotify
+
+import (
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// WithRoundTripper installs a middleware around the transport of the client's
+// HTTP client, for example to sign requests for a proxy, inject failures or
+// record custom metrics.  The middleware receives the transport it wraps and
+// returns the one the client should use.  Middlewares see every attempt made
+// by the client, including retries.
+//
+// Each WithRoundTripper wraps the transports installed before it, so the last
+// middleware given is the first to see a request.  The oauth2 transport stays
+// at the base of the chain: requests are still authenticated and Token keeps
+// working.  The HTTP client passed to New is left untouched.
+//
+// middleware is called once, when the option is applied.
+func WithRoundTripper(middleware func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		next := client.http.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		if base, ok := next.(*oauth2.Transport); ok {
+			// Let each client sharing the chain pick its own oauth2
+			// transport, so that WithTokenNotify can swap it without
+			// rebuilding the middlewares.
+			owned := *base
+			client.oauth2 = &owned
+			next = &authTransport{fallback: &owned}
+		}
+
+		httpClient := *client.http
+		httpClient.Transport = middleware(next)
+		client.http = &httpClient
+	}
+}
+
+type oauth2TransportKey struct{}
+
+// authTransport is the base of a chain built by WithRoundTripper.  It
+// authenticates requests with the oauth2 transport of the client sending
+// them, carried by the request's context, or with fallback for requests
+// made outside of a client, for example by a middleware.
+type authTransport struct {
+	fallback *oauth2.Transport
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if base, ok := req.Context().Value(oauth2TransportKey{}).(*oauth2.Transport); ok {
+		return base.RoundTrip(req)
+	}
+	return t.fallback.RoundTrip(req)
+}
+
+// oauth2Transport returns the oauth2 transport authenticating c's requests.
+func (c *Client) oauth2Transport() (*oauth2.Transport, bool) {
+	if c.oauth2 != nil {
+		return c.oauth2, true
+	}
+	transport, ok := c.http.Transport.(*oauth2.Transport)
+	return transport, ok
+}
@@ -0,0 +1,155 @@
+package spotify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// tagging returns a middleware that appends name to calls for every request.
+func tagging(name string, calls *[]string) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func TestWithRoundTripperSeesRetries(t *testing.T) {
+	attempts := 0
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		auth = append(auth, r.Header.Get("Authorization"))
+		if attempts <= 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(rateLimitExceededStatusCode)
+			return
+		}
+		_, _ = io.WriteString(w, `{"genres": []}`)
+	}))
+	defer server.Close()
+
+	var calls []string
+	httpClient := &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+	}}
+	client := New(httpClient,
+		WithBaseURL(server.URL+"/"),
+		WithRetry(true),
+		WithRoundTripper(tagging("counter", &calls)),
+	)
+	client.clock = &fakeClock{}
+
+	if _, err := client.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 {
+		t.Errorf("Expected the middleware to see 3 attempts, got %d", len(calls))
+	}
+	for _, a := range auth {
+		if a != "Bearer token" {
+			t.Errorf("Expected requests to be authenticated, got %q", a)
+		}
+	}
+	if _, ok := httpClient.Transport.(*oauth2.Transport); !ok {
+		t.Error("The caller's HTTP client should not be modified")
+	}
+	if token, err := client.Token(); err != nil || token.AccessToken != "token" {
+		t.Errorf("Token should unwrap the middleware: %v", err)
+	}
+}
+
+func TestWithRoundTripperOrder(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"genres": []}`)
+	defer server.Close()
+
+	var calls []string
+	WithRoundTripper(tagging("first", &calls))(client)
+	WithRoundTripper(tagging("second", &calls))(client)
+
+	if _, err := client.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0] != "second" || calls[1] != "first" {
+		t.Errorf("Expected the last middleware to run first, got %v", calls)
+	}
+}
+
+func TestWithTokenNotifyAfterRoundTripper(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"genres": []}`)
+	defer server.Close()
+	client.http = &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}),
+	}}
+
+	var calls, notified []string
+	WithRoundTripper(tagging("middleware", &calls))(client)
+	WithTokenNotify(func(t *oauth2.Token) {
+		notified = append(notified, t.AccessToken)
+	})(client)
+
+	if _, err := client.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("Expected the middleware to be kept, got %v", calls)
+	}
+	if len(notified) != 1 || notified[0] != "token" {
+		t.Errorf("Expected a notification for the token, got %v", notified)
+	}
+}
+
+func TestWithTokenNotifyKeepsMiddlewares(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"genres": []}`)
+	}))
+	defer server.Close()
+	client := New(&http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}),
+	}}, WithBaseURL(server.URL+"/"))
+
+	built := 0
+	var calls, notified, cloneNotified []string
+	counting := func(next http.RoundTripper) http.RoundTripper {
+		built++
+		return tagging("middleware", &calls)(next)
+	}
+	WithRoundTripper(counting)(client)
+	WithTokenNotify(func(t *oauth2.Token) { notified = append(notified, t.AccessToken) })(client)
+	if built != 1 {
+		t.Errorf("Expected the middleware to be built once, got %d", built)
+	}
+
+	clone := client.Clone(WithTokenNotify(func(t *oauth2.Token) { cloneNotified = append(cloneNotified, t.AccessToken) }))
+	if built != 1 {
+		t.Errorf("Expected the clone to reuse the middleware, got %d builds", built)
+	}
+	if _, err := client.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(notified) != 1 || len(cloneNotified) != 0 {
+		t.Errorf("Expected only the original to be notified, got %v and %v", notified, cloneNotified)
+	}
+	if _, err := clone.GetAvailableGenreSeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(cloneNotified) != 1 || cloneNotified[0] != "token" {
+		t.Errorf("Expected the clone to be notified, got %v", cloneNotified)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Expected both clients to keep the middleware, got %v", calls)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 )
 
 // User contains the basic, publicly available information about a Spotify user.
@@ -136,6 +137,101 @@ func (c *Client) CurrentUsersTracks(ctx context.Context, opts ...RequestOption)
 	return &result, nil
 }
 
+// defaultPagePrefetch is the number of pages fetched concurrently by the
+// clients created with New unless configured otherwise with WithPagePrefetch.
+const defaultPagePrefetch = 4
+
+// WithPagePrefetch configures how many pages AllSavedTracks fetches
+// concurrently once it knows the size of the library.  A value of 0 or 1
+// fetches the pages one after the other, which suits applications with
+// tight rate limits.  Clients created with New fetch 4 pages at a time.
+func WithPagePrefetch(n int) ClientOption {
+	return func(client *Client) {
+		client.pagePrefetch = n
+	}
+}
+
+// AllSavedTracks gets every song saved in the current Spotify user's
+// "Your Music" library, walking through the pages of CurrentUsersTracks
+// 50 tracks at a time.  Once the first page has reported the size of the
+// library, the remaining pages are fetched concurrently, as configured with
+// WithPagePrefetch.  Tracks are returned in library order.  The walk stops
+// as soon as ctx is done or a page can't be fetched.
+//
+// Supported options: Market
+func (c *Client) AllSavedTracks(ctx context.Context, opts ...RequestOption) ([]SavedTrack, error) {
+	const pageSize = 50
+	pageOpts := func(offset int) []RequestOption {
+		return append(append([]RequestOption{}, opts...), Limit(pageSize), Offset(offset))
+	}
+
+	first, err := c.CurrentUsersTracks(ctx, pageOpts(0)...)
+	if err != nil {
+		return nil, err
+	}
+	pages := make([][]SavedTrack, (first.Total+pageSize-1)/pageSize)
+	if len(pages) <= 1 {
+		return first.Tracks, nil
+	}
+	pages[0] = first.Tracks
+
+	prefetch := c.pagePrefetch
+	if prefetch < 1 {
+		prefetch = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, prefetch)
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+walk:
+	for i := 1; i < len(pages); i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break walk
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			page, err := c.CurrentUsersTracks(ctx, pageOpts(i*pageSize)...)
+			if err != nil {
+				fail(err)
+				return
+			}
+			pages[i] = page.Tracks
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tracks := make([]SavedTrack, 0, first.Total)
+	for _, page := range pages {
+		tracks = append(tracks, page...)
+	}
+	return tracks, nil
+}
+
 // FollowUser adds the current user as a follower of one or more
 // spotify users, identified by their Spotify IDs.
 //
@@ -2,12 +2,18 @@ package spotify
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 const userResponse = `
@@ -446,3 +452,135 @@ func TestCurrentUsersTopTracks(t *testing.T) {
 		t.Errorf("Wrong ISRC: want %s, got %s\n", isrc, i)
 	}
 }
+
+// savedTracksServer serves a library of total saved tracks, paged by the
+// offset and limit query parameters.  onRequest is called before each page
+// is served.
+func savedTracksServer(total int, onRequest func(offset int)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if onRequest != nil {
+			onRequest(offset)
+		}
+		var items []string
+		for i := offset; i < offset+limit && i < total; i++ {
+			items = append(items, fmt.Sprintf(`{"added_at": "2015-01-31T00:37:55Z", "track": {"id": "track%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"items": [%s], "limit": %d, "offset": %d, "total": %d}`,
+			strings.Join(items, ","), limit, offset, total)
+	}))
+}
+
+func TestAllSavedTracks(t *testing.T) {
+	var mu sync.Mutex
+	var offsets []int
+	server := savedTracksServer(120, func(offset int) {
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+	})
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	tracks, err := client.AllSavedTracks(context.Background(), Market(CountryUSA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 120 {
+		t.Fatalf("Expected 120 tracks, got %d", len(tracks))
+	}
+	for i, track := range tracks {
+		if want := ID(fmt.Sprintf("track%d", i)); track.ID != want {
+			t.Fatalf("Track %d out of order: got %s, want %s", i, track.ID, want)
+		}
+	}
+	sort.Ints(offsets)
+	if !reflect.DeepEqual(offsets, []int{0, 50, 100}) {
+		t.Errorf("Expected pages at offsets [0 50 100], got %v", offsets)
+	}
+}
+
+func TestAllSavedTracksSinglePage(t *testing.T) {
+	server := savedTracksServer(3, nil)
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	tracks, err := client.AllSavedTracks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 3 {
+		t.Errorf("Expected 3 tracks, got %d", len(tracks))
+	}
+}
+
+func TestAllSavedTracksCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	requests := 0
+	server := savedTracksServer(1000, func(offset int) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if offset >= 100 {
+			cancel()
+		}
+	})
+	defer server.Close()
+	client := &Client{http: http.DefaultClient, baseURL: server.URL + "/"}
+
+	_, err := client.AllSavedTracks(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests >= 20 {
+		t.Errorf("Expected the walk to stop early, got %d requests", requests)
+	}
+}
+
+func TestAllSavedTracksPrefetch(t *testing.T) {
+	if c := New(http.DefaultClient); c.pagePrefetch != defaultPagePrefetch {
+		t.Errorf("Expected a default prefetch of %d, got %d", defaultPagePrefetch, c.pagePrefetch)
+	}
+
+	for _, prefetch := range []int{0, 1} {
+		var mu sync.Mutex
+		var offsets []int
+		inflight, maxInflight := 0, 0
+		server := savedTracksServer(300, func(offset int) {
+			mu.Lock()
+			inflight++
+			if inflight > maxInflight {
+				maxInflight = inflight
+			}
+			offsets = append(offsets, offset)
+			mu.Unlock()
+			// give concurrent requests a chance to overlap
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			inflight--
+			mu.Unlock()
+		})
+		client := New(http.DefaultClient, WithBaseURL(server.URL+"/"), WithPagePrefetch(prefetch))
+
+		tracks, err := client.AllSavedTracks(context.Background())
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tracks) != 300 {
+			t.Fatalf("Expected 300 tracks, got %d", len(tracks))
+		}
+		if maxInflight != 1 {
+			t.Errorf("Prefetch %d: expected sequential requests, got %d at once", prefetch, maxInflight)
+		}
+		if !reflect.DeepEqual(offsets, []int{0, 50, 100, 150, 200, 250}) {
+			t.Errorf("Prefetch %d: expected pages in order, got %v", prefetch, offsets)
+		}
+	}
+}